@@ -0,0 +1,896 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"adventofcode/internal/answers"
+	"adventofcode/internal/api"
+	"adventofcode/internal/bench"
+	"adventofcode/internal/dashboard"
+	"adventofcode/internal/day"
+	_ "adventofcode/internal/days"
+	"adventofcode/internal/difftest"
+	"adventofcode/internal/fetch"
+	"adventofcode/internal/profiling"
+	"adventofcode/internal/scaffold"
+	"adventofcode/internal/secrets"
+	"adventofcode/internal/submit"
+)
+
+const usage = `usage: aoc <command> [arguments]
+
+commands:
+  list                        list all registered days, and their parameters
+  run <day> [flags]           solve a single day, e.g. 7, 7b or 2024/7; days
+                              without a year are of the latest year
+  run all [flags]             solve all days
+  fetch <year>/<day>          download the input for a day into the inputs
+                              directory; needs AOC_SESSION or a session file
+  bench [flags] [day ...]     time parsing and both parts of days; -n sets the
+                              number of runs, -json writes JSON, -timeout
+                              limits each part; results are kept in
+                              bench.jsonl in the inputs directory
+  bench compare [old [new]]   compare two benchmark runs by commit, or last-n
+                              for the n-th run before the last
+  new [<year>/]<day> [flags]  create the package for a new day from templates;
+                              -variant b creates a variant of an existing day
+  pgo [-n runs] [-o file]     profile a run of all days, and merge it with the
+                              profiles kept by run -pgo-collect into
+                              cmd/aoc/default.pgo for profile-guided builds
+  verify [flags] [day ...]    solve days and compare the answers with the
+                              recorded ones; -record records missing answers,
+                              -hash records them hashed, -timeout limits
+                              each day
+  submit <day> <part> [flags] solve a part of a day and send its answer; takes
+                              -i, -inputs and the parameters of the day, and
+                              keeps every attempt in history.jsonl in the
+                              inputs directory
+  diff [flags] [day ...]      check that the variants of days agree on the
+                              examples, the input and -n random inputs, and
+                              show the smallest input they disagree on
+  encrypt [-k] [file ...]     encrypt inputs and answers files into file.enc
+                              and remove the plain files, unless -k; without
+                              files, all of them and the submit history;
+                              creates a key if needed
+  decrypt [-c] file.enc ...   decrypt files next to the encrypted ones, or to
+                              stdout with -c; the key is read from AOC_KEY or
+                              the key file, see AOC_KEY_FILE
+  serve [flags]               serve a dashboard of all days on -addr, with their
+                              answers, timings and bench history, and an API
+                              solving inputs posted to /v1/solve/<day>; takes
+                              -answers, -inputs, -history, -timeout, -j and
+                              -max-input
+
+run flags:
+  -j n                        solve at most n parts at the same time (default
+                              the number of CPUs)
+  -check                      fail parts that change the state of their day
+  -time                       print how long each part took
+  -format f                   plain (the default) prints the answers, table
+                              and json also the type of each answer, the time
+                              it took, the hash of the input and any error
+  -timeout d                  stop parts that take longer than d, e.g. 10s;
+                              they are reported as timed out; parts of days
+                              that can't be cancelled keep running in the
+                              background, and no longer count towards -j
+  -i file                     read the input from file; - reads stdin
+  -e example                  read an embedded example, e.g. example.txt
+  -inputs dir                 inputs directory (default $AOC_INPUTS or the
+                              user cache directory)
+  -cpuprofile file            write a CPU profile
+  -memprofile file            write an allocation profile
+  -trace file                 write an execution trace
+  -pgo-collect                keep the CPU profile in the pgo directory of the
+                              inputs directory, for aoc pgo, replacing the one
+                              of an earlier run of the same days
+  -<param> n                  set a parameter of a single day, e.g. -width 11
+                              for day 14; list shows the parameters of all days
+`
+
+func list() {
+	for _, p := range day.Puzzles() {
+		fmt.Print(p.Name())
+		for _, param := range p.Params {
+			fmt.Printf(" -%s %d", param.Name, param.Default)
+		}
+		fmt.Println()
+	}
+}
+
+func run(args []string) {
+	if len(args) == 0 {
+		log.Fatal("run: missing day")
+	}
+
+	var puzzles []day.Puzzle
+
+	if args[0] == "all" {
+		puzzles = day.Puzzles()
+	} else {
+		p, ok := day.Lookup(args[0])
+		if !ok {
+			log.Fatalf("run: unknown day %q", args[0])
+		}
+		puzzles = []day.Puzzle{p}
+	}
+
+	fset := flag.NewFlagSet("run", flag.ExitOnError)
+	input := day.InputFlags(fset)
+
+	// parameters are only given for a single day
+	var declared []day.Param
+	if len(puzzles) == 1 {
+		declared = puzzles[0].Params
+	}
+	params := day.ParamFlags(fset, declared)
+	workers := fset.Int("j", 0, "number of parts solved at the same time (default the number of CPUs)")
+	check := fset.Bool("check", false, "fail parts that change the state of their day")
+	timing := fset.Bool("time", false, "print how long each part took, in plain output")
+	timeout := fset.Duration("timeout", 0, "time limit for each part of a day, e.g. 10s (default no limit)")
+	formatName := fset.String("format", "plain", "output format: plain, table or json")
+	var prof profiling.Profiler
+	prof.AddFlags(fset)
+	fset.Parse(args[1:])
+
+	format, err := day.ParseFormat(*formatName)
+	if err != nil {
+		log.Fatalf("run: %v", err)
+	}
+
+	prof.Dir = pgoDir(fset.Lookup("inputs").Value.String())
+	// a profile of the same days replaces the one collected before
+	prof.Name = "all"
+	if len(puzzles) == 1 {
+		prof.Name = strings.ReplaceAll(puzzles[0].Name(), "/", "-")
+	}
+	if err := prof.Start(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	s := day.Scheduler{Workers: *workers, Check: *check, Timeout: *timeout}
+	out := day.NewResultWriter(os.Stdout, format)
+	out.Headers = len(puzzles) > 1
+	out.Elapsed = *timing
+	failed := false
+
+	s.Run(ctx, day.Tasks(puzzles), func(r day.Result) {
+		if r.Err != nil {
+			failed = true
+			if format == day.Plain {
+				log.Printf("day %s: part %d: %v", r.Puzzle.Name(), r.Part, r.Err)
+			}
+		}
+
+		if err := out.Add(r); err != nil {
+			log.Fatalf("run: %v", err)
+		}
+	}, input(), params())
+
+	if err := out.Flush(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+
+	if err := prof.Stop(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// pgoDir returns the directory where profiles are collected for aoc pgo.
+func pgoDir(inputs string) string {
+	dir, err := day.InputsDir(inputs)
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "pgo")
+}
+
+// pgo profiles a run of all days, and merges the profile with the ones
+// collected with run -pgo-collect into the profile that go build uses for
+// profile-guided optimisation.
+func pgo(args []string) {
+	fset := flag.NewFlagSet("pgo", flag.ExitOnError)
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	n := fset.Int("n", 1, "number of runs of all days to profile")
+	output := fset.String("o", "", "profile to write (default default.pgo in cmd/aoc)")
+	fset.Parse(args)
+
+	if *output == "" {
+		root, err := moduleRoot()
+		if err != nil {
+			log.Fatalf("pgo: %v", err)
+		}
+		*output = filepath.Join(root, "cmd", "aoc", "default.pgo")
+	}
+
+	tmp, err := os.CreateTemp("", "aoc-*.pprof")
+	if err != nil {
+		log.Fatalf("pgo: %v", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	prof := profiling.Profiler{CPUProfile: tmp.Name()}
+	if err := prof.Start(); err != nil {
+		log.Fatalf("pgo: %v", err)
+	}
+
+	failed := make(map[string]bool)
+
+	// parse on every run, so that parsing is in the profile too
+	for range *n {
+		day.Scheduler{}.Run(ctx, day.Tasks(day.Puzzles()), func(r day.Result) {
+			if r.Err != nil && !failed[r.Puzzle.Name()] {
+				log.Printf("pgo: skipping day %s: %v", r.Puzzle.Name(), r.Err)
+				failed[r.Puzzle.Name()] = true
+			}
+		}, day.WithInputsDir(*inputs), day.WithoutCache())
+	}
+
+	if err := prof.Stop(); err != nil {
+		log.Fatalf("pgo: %v", err)
+	}
+
+	collected, err := profiling.Collected(pgoDir(*inputs))
+	if err != nil {
+		log.Fatalf("pgo: %v", err)
+	}
+
+	if err := profiling.Merge(ctx, *output, append(collected, tmp.Name())...); err != nil {
+		log.Fatalf("pgo: %v", err)
+	}
+
+	fmt.Printf("merged %d runs of all days and %d collected profiles into %s\n", *n, len(collected), *output)
+}
+
+func fetchInput(args []string) {
+	fset := flag.NewFlagSet("fetch", flag.ExitOnError)
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	baseURL := fset.String("url", fetch.DefaultBaseURL, "base URL of the website")
+
+	// the year and day are one argument, 2024/7, or two, 2024 7
+	if len(args) >= 2 && !strings.Contains(args[0], "/") {
+		args = append([]string{args[0] + "/" + args[1]}, args[2:]...)
+	}
+	if len(args) < 1 || !strings.Contains(args[0], "/") {
+		log.Fatal("fetch: usage: fetch <year>/<day> [-inputs dir]")
+	}
+	fset.Parse(args[1:])
+
+	year, number, err := parseDay(args[0])
+	if err != nil {
+		log.Fatalf("fetch: %v", err)
+	}
+
+	dir, err := day.InputsDir(*inputs)
+	if err != nil {
+		log.Fatalf("fetch: %v", err)
+	}
+
+	// a missing session only matters when the input is not cached yet
+	session, err := fetch.Session()
+	if err != nil && !errors.Is(err, fetch.ErrNoSession) {
+		log.Fatalf("fetch: %v", err)
+	}
+
+	c := fetch.New(dir, session)
+	c.BaseURL = *baseURL
+
+	if _, err := c.Input(context.Background(), year, number); err != nil {
+		log.Fatalf("fetch: %v", err)
+	}
+
+	fmt.Println(c.Path(year, number))
+}
+
+// encryptFiles encrypts inputs and answers files with the local key, and
+// creates the key when there is none yet. Without files, it encrypts the
+// inputs and answers of all days that are still plain, and the history of
+// submitted answers.
+func encryptFiles(args []string) {
+	fset := flag.NewFlagSet("encrypt", flag.ExitOnError)
+	keep := fset.Bool("k", false, "keep the plain files")
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	dir := fset.String("answers", "", "answers directory (default $AOC_ANSWERS or answers)")
+	fset.Parse(args)
+
+	key, err := secrets.LoadKey()
+	if errors.Is(err, secrets.ErrNoKey) {
+		if key, err = secrets.NewKey(); err == nil {
+			var path string
+			if path, err = secrets.SaveKey(key); err == nil {
+				log.Printf("encrypt: created a new key in %s; keep a copy, files can't be read without it", path)
+			}
+		}
+	}
+	if err != nil {
+		log.Fatalf("encrypt: %v", err)
+	}
+
+	files := fset.Args()
+
+	if len(files) == 0 {
+		inputsDir, err := day.InputsDir(*inputs)
+		if err != nil {
+			log.Fatalf("encrypt: %v", err)
+		}
+		answersDir := answers.Dir(*dir)
+
+		if history := filepath.Join(inputsDir, submit.HistoryFile); fileExists(history) {
+			files = append(files, history)
+		}
+
+		for _, p := range day.Puzzles() {
+			for _, path := range []string{day.InputPath(inputsDir, p.Year, p.Day), answers.Path(answersDir, p.Year, p.Day)} {
+				// variants share the files of their day
+				if fileExists(path) && !slices.Contains(files, path) {
+					files = append(files, path)
+				}
+			}
+		}
+	}
+
+	for _, name := range files {
+		encrypted, err := secrets.EncryptFile(key, name, *keep)
+		if err != nil {
+			log.Fatalf("encrypt: %v", err)
+		}
+		fmt.Println(encrypted)
+	}
+}
+
+// fileExists reports whether there is a file called name.
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+// decryptFiles decrypts files next to the encrypted ones, or to standard
+// output with -c.
+func decryptFiles(args []string) {
+	fset := flag.NewFlagSet("decrypt", flag.ExitOnError)
+	stdout := fset.Bool("c", false, "write to standard output, and keep no plain files")
+	fset.Parse(args)
+
+	if fset.NArg() == 0 {
+		log.Fatal("decrypt: usage: decrypt [-c] file.enc ...")
+	}
+
+	key, err := secrets.LoadKey()
+	if err != nil {
+		log.Fatalf("decrypt: %v", err)
+	}
+
+	for _, name := range fset.Args() {
+		if *stdout {
+			data, err := os.ReadFile(name)
+			if err == nil {
+				data, err = secrets.Decrypt(key, data)
+			}
+			if err != nil {
+				log.Fatalf("decrypt: %s: %v", name, err)
+			}
+			os.Stdout.Write(data)
+			continue
+		}
+
+		plain, err := secrets.DecryptFile(key, name)
+		if err != nil {
+			log.Fatalf("decrypt: %v", err)
+		}
+		fmt.Println(plain)
+	}
+}
+
+func submitAnswer(args []string) {
+	if len(args) < 2 {
+		log.Fatal("submit: usage: submit <day> <part> [flags]")
+	}
+
+	p, ok := day.Lookup(args[0])
+	if !ok {
+		log.Fatalf("submit: unknown day %q", args[0])
+	}
+
+	part, err := strconv.Atoi(args[1])
+	if err != nil || part < 1 || part > 2 {
+		log.Fatalf("submit: bad part %q", args[1])
+	}
+
+	fset := flag.NewFlagSet("submit", flag.ExitOnError)
+	input := day.InputFlags(fset)
+	params := day.ParamFlags(fset, p.Params)
+	fset.Parse(args[2:])
+
+	// only answers for the real input are worth sending
+	if fset.Lookup("e").Value.String() != "" {
+		log.Fatal("submit: -e: examples can't be submitted, use -i or the inputs directory")
+	}
+
+	dir, err := day.InputsDir(fset.Lookup("inputs").Value.String())
+	if err != nil {
+		log.Fatalf("submit: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	answer, err := p.Part(ctx, part, input(), params())
+	if err != nil {
+		log.Fatalf("day %s: part %d: %v", p.Name(), part, err)
+	}
+
+	session, err := fetch.Session()
+	if err != nil {
+		log.Fatalf("submit: %v", err)
+	}
+
+	history, err := submit.LoadHistory(filepath.Join(dir, submit.HistoryFile))
+	if err != nil {
+		log.Fatalf("submit: %v", err)
+	}
+
+	s := submit.Submitter{Client: fetch.New(dir, session), History: history}
+
+	a, err := s.Submit(ctx, p.Year, p.Day, part, answer)
+	if err != nil {
+		log.Fatalf("submit: day %s part %d: %v", p.Name(), part, err)
+	}
+
+	fmt.Printf("day %s part %d: %s: %s\n", p.Name(), part, answer, a.Result)
+	if !a.Until.IsZero() {
+		fmt.Printf("next answer after %s\n", a.Until.Format(time.TimeOnly))
+	}
+
+	if a.Result != submit.Correct {
+		os.Exit(1)
+	}
+}
+
+// selectPuzzles looks up the named puzzles, or returns all of them when
+// there are no names.
+func selectPuzzles(cmd string, names []string) []day.Puzzle {
+	if len(names) == 0 {
+		return day.Puzzles()
+	}
+
+	var result []day.Puzzle
+
+	for _, name := range names {
+		p, ok := day.Lookup(name)
+		if !ok {
+			log.Fatalf("%s: unknown day %q", cmd, name)
+		}
+		result = append(result, p)
+	}
+
+	return result
+}
+
+func verify(args []string) {
+	fset := flag.NewFlagSet("verify", flag.ExitOnError)
+	dir := fset.String("answers", "", "answers directory (default $AOC_ANSWERS or answers)")
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	record := fset.Bool("record", false, "record answers for parts without a recorded answer")
+	hash := fset.Bool("hash", false, "record hashes instead of the answers")
+	timeout := fset.Duration("timeout", 0, "time limit for verifying each day, e.g. 10s (default no limit)")
+	fset.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	puzzles := selectPuzzles("verify", fset.Args())
+	*dir = answers.Dir(*dir)
+	var all []answers.Result
+	failed := false
+
+	for _, p := range puzzles {
+		results, err := verifyDay(ctx, *dir, p, *timeout, day.WithInputsDir(*inputs))
+		if err != nil {
+			log.Fatalf("verify: %v", err)
+		}
+		if err := answers.SaveRun(*dir, p, time.Now(), results); err != nil {
+			log.Fatalf("verify: %v", err)
+		}
+
+		if *record {
+			if err := recordAnswers(*dir, p, results, *hash); err != nil {
+				log.Fatalf("verify: %v", err)
+			}
+		}
+
+		for _, r := range results {
+			failed = failed || r.Status == answers.Regression || r.Status == answers.Failed
+		}
+
+		all = append(all, results...)
+	}
+
+	answers.WriteTable(os.Stdout, all)
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// verifyDay verifies p like answers.Verify, failing the parts that are
+// left after timeout, unless it is zero.
+func verifyDay(ctx context.Context, dir string, p day.Puzzle, timeout time.Duration, opts ...day.Option) ([]answers.Result, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return answers.Verify(ctx, dir, p, opts...)
+}
+
+// recordAnswers saves the answers of the parts that have no recorded answer
+// yet. Recorded answers are never overwritten, so that a regression can't
+// be recorded by accident.
+func recordAnswers(dir string, p day.Puzzle, results []answers.Result, hash bool) error {
+	r, err := answers.Load(dir, p.Year, p.Day)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+
+	for _, result := range results {
+		if result.Status == answers.Unrecorded {
+			r.Set(p.Year, p.Day, result.Part, result.Got, hash)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return answers.Save(dir, p.Year, p.Day, r)
+}
+
+func benchmark(args []string) {
+	if len(args) > 0 && args[0] == "compare" {
+		compareBenchmarks(args[1:])
+		return
+	}
+
+	fset := flag.NewFlagSet("bench", flag.ExitOnError)
+	n := fset.Int("n", 5, "number of runs per day")
+	asJSON := fset.Bool("json", false, "write the results as JSON")
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	history := fset.String("history", "", "history file (default bench.jsonl in the inputs directory)")
+	save := fset.Bool("save", true, "save the results in the history")
+	timeout := fset.Duration("timeout", 0, "time limit for each part of a day, e.g. 10s (default no limit)")
+	fset.Parse(args)
+
+	if *n < 1 {
+		log.Fatal("bench: -n must be at least 1")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	record := bench.Record{Time: time.Now()}
+	failed := false
+
+	for _, p := range selectPuzzles("bench", fset.Args()) {
+		hash, _ := bench.InputHash(p, day.WithInputsDir(*inputs))
+
+		for _, r := range bench.Run(ctx, p, *n, *timeout, day.WithInputsDir(*inputs)) {
+			r.Input = hash
+			failed = failed || r.Err != ""
+			record.Results = append(record.Results, r)
+		}
+	}
+
+	write := bench.WriteTable
+	if *asJSON {
+		write = bench.WriteJSON
+	}
+
+	if err := write(os.Stdout, record.Results); err != nil {
+		log.Fatalf("bench: %v", err)
+	}
+
+	if *save {
+		commit, err := bench.Commit(".")
+		if err != nil {
+			log.Printf("bench: saving without a commit: %v", err)
+		}
+		record.Commit = commit
+
+		if err := bench.AppendHistory(benchHistory(*history, *inputs), record); err != nil {
+			log.Fatalf("bench: %v", err)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func benchHistory(history, inputs string) string {
+	if history != "" {
+		return history
+	}
+
+	dir, err := day.InputsDir(inputs)
+	if err != nil {
+		log.Fatalf("bench: %v", err)
+	}
+
+	return filepath.Join(dir, "bench.jsonl")
+}
+
+func compareBenchmarks(args []string) {
+	fset := flag.NewFlagSet("bench compare", flag.ExitOnError)
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	history := fset.String("history", "", "history file (default bench.jsonl in the inputs directory)")
+	fset.Usage = func() {
+		fmt.Fprintln(fset.Output(), "usage: aoc bench compare [flags] [old [new]]")
+		fmt.Fprintln(fset.Output(), "old and new are commits, last, or last-n for the n-th run before the last; they default to last-1 and last")
+		fset.PrintDefaults()
+	}
+	fset.Parse(args)
+
+	refs := append(fset.Args(), "last-1", "last")[:2]
+	if fset.NArg() == 1 {
+		refs[1] = "last"
+	}
+	if fset.NArg() > 2 {
+		fset.Usage()
+		os.Exit(2)
+	}
+
+	records, err := bench.LoadHistory(benchHistory(*history, *inputs))
+	if err != nil {
+		log.Fatalf("bench: %v", err)
+	}
+
+	old, err := bench.Find(records, refs[0])
+	if err != nil {
+		log.Fatalf("bench: %v", err)
+	}
+
+	new, err := bench.Find(records, refs[1])
+	if err != nil {
+		log.Fatalf("bench: %v", err)
+	}
+
+	comparisons := bench.Compare(old, new)
+
+	if err := bench.WriteComparison(os.Stdout, old, new, comparisons); err != nil {
+		log.Fatalf("bench: %v", err)
+	}
+
+	for _, c := range comparisons {
+		if c.Change == bench.Slower {
+			os.Exit(1)
+		}
+	}
+}
+
+func newDay(args []string) {
+	fset := flag.NewFlagSet("new", flag.ExitOnError)
+	variant := fset.String("variant", "", "variant of an existing day, e.g. b")
+
+	if len(args) < 1 {
+		log.Fatal("new: usage: new [<year>/]<day> [-variant b]")
+	}
+	fset.Parse(args[1:])
+
+	year, number, err := parseDay(args[0])
+	if err != nil {
+		log.Fatalf("new: %v", err)
+	}
+
+	root, err := moduleRoot()
+	if err != nil {
+		log.Fatalf("new: %v", err)
+	}
+
+	files, err := scaffold.Generate(root, year, number, *variant)
+	if err != nil {
+		log.Fatalf("new: %v", err)
+	}
+
+	for _, f := range files {
+		if rel, err := filepath.Rel(root, f); err == nil {
+			f = rel
+		}
+		fmt.Println(f)
+	}
+}
+
+// parseDay parses a day written as year/day, e.g. 2024/7, or as a day of
+// the latest registered year.
+func parseDay(s string) (year, number int, err error) {
+	yearText, dayText, ok := strings.Cut(s, "/")
+	if !ok {
+		years := day.Years()
+		if len(years) == 0 {
+			return 0, 0, fmt.Errorf("no year in %q", s)
+		}
+		year, dayText = years[len(years)-1], s
+	} else if year, err = strconv.Atoi(yearText); err != nil {
+		return 0, 0, fmt.Errorf("bad year %q", yearText)
+	}
+
+	number, err = strconv.Atoi(dayText)
+	if err != nil || number < 1 || number > 25 {
+		return 0, 0, fmt.Errorf("bad day %q", dayText)
+	}
+
+	return year, number, nil
+}
+
+// diffVariants checks that the variants of the named days, or of all days,
+// agree.
+func diffVariants(args []string) {
+	fset := flag.NewFlagSet("diff", flag.ExitOnError)
+	runs := fset.Int("n", 100, "number of random inputs")
+	seed := fset.Uint64("seed", 1, "seed of the random inputs")
+	timeout := fset.Duration("timeout", 10*time.Second, "time limit for a part on an input")
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	fset.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := difftest.Checker{Runs: *runs, Seed: *seed, Timeout: *timeout, InputsDir: *inputs}
+	failed := false
+
+	groups := difftest.Groups(selectPuzzles("diff", fset.Args()))
+	if len(groups) == 0 {
+		log.Fatal("diff: no days with more than one variant")
+	}
+
+	for _, puzzles := range groups {
+		r, err := c.Check(ctx, puzzles)
+		if err != nil {
+			log.Fatalf("diff: %v", err)
+		}
+
+		if len(r.Disagreements) == 0 {
+			fmt.Printf("day %s: %d variants agree; examples: %d, random inputs: %d, input: %t\n",
+				puzzles[0].Name(), len(puzzles), r.Examples, r.Random, r.Input)
+			continue
+		}
+
+		failed = true
+		for _, d := range r.Disagreements {
+			fmt.Printf("day %s: %v\n", puzzles[0].Name(), d)
+			if d.Data != nil {
+				fmt.Printf("%s\n", d.Data)
+			}
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// serve serves the dashboard and the API until it is interrupted.
+func serve(args []string) {
+	fset := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fset.String("addr", "localhost:8080", "address to listen on")
+	dir := fset.String("answers", "", "answers directory (default $AOC_ANSWERS or answers)")
+	inputs := fset.String("inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+	history := fset.String("history", "", "bench history file (default bench.jsonl in the inputs directory)")
+	timeout := fset.Duration("timeout", time.Minute, "time limit for verifying a day, and for a request to the API")
+	workers := fset.Int("j", 0, "number of API requests solved at the same time (default the number of CPUs)")
+	maxInput := fset.Int64("max-input", api.DefaultMaxInput, "largest input accepted by the API, in bytes")
+	fset.Parse(args)
+
+	d := dashboard.New(answers.Dir(*dir), *inputs, benchHistory(*history, *inputs))
+	d.Timeout = *timeout
+
+	a := api.New(*workers)
+	a.Timeout, a.MaxInput = *timeout, *maxInput
+
+	mux := http.NewServeMux()
+	mux.Handle("/", d)
+	mux.Handle("/v1/", a)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	server := &http.Server{Addr: *addr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	log.Printf("serving the dashboard on http://%s, and the API on /v1/solve/", *addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("serve: %v", err)
+	}
+}
+
+// moduleRoot returns the directory holding go.mod, starting from the
+// working directory.
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not in the module, no go.mod found")
+		}
+		dir = parent
+	}
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("aoc: ")
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "list":
+		list()
+	case "run":
+		run(os.Args[2:])
+	case "fetch":
+		fetchInput(os.Args[2:])
+	case "bench":
+		benchmark(os.Args[2:])
+	case "new":
+		newDay(os.Args[2:])
+	case "pgo":
+		pgo(os.Args[2:])
+	case "verify":
+		verify(os.Args[2:])
+	case "submit":
+		submitAnswer(os.Args[2:])
+	case "encrypt":
+		encryptFiles(os.Args[2:])
+	case "decrypt":
+		decryptFiles(os.Args[2:])
+	case "serve":
+		serve(os.Args[2:])
+	case "diff":
+		diffVariants(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
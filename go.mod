@@ -1,5 +1,5 @@
-module adventofcode2024
+module adventofcode
 
-go 1.24
+go 1.24.0
 
 require github.com/cespare/xxhash/v2 v2.3.0
@@ -0,0 +1,156 @@
+// Package answers keeps the known answers for the real inputs, so that a
+// refactored solution can be checked against them.
+//
+// The answers for a day are kept in a file per day, <dir>/2024/day07.txt,
+// with a line per part:
+//
+//	part1 3749
+//	part2 sha256:0a3f...
+//
+// An answer is either stored as is, or as a hash, so that the answers file
+// can be committed without giving the answer away. The whole file can also
+// be kept encrypted, as day07.txt.enc, see package secrets; it stays
+// encrypted when answers are recorded. Alternate solutions of a day share
+// its answers.
+package answers
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/secrets"
+)
+
+const hashPrefix = "sha256:"
+
+// Record holds the recorded answers for both parts of a day. An empty part
+// has no recorded answer.
+type Record struct {
+	Part1, Part2 string
+}
+
+// Dir returns dir, or when it is empty, the answers directory from the
+// AOC_ANSWERS environment variable, and "answers" otherwise.
+func Dir(dir string) string {
+	if dir != "" {
+		return dir
+	}
+
+	if dir := os.Getenv("AOC_ANSWERS"); dir != "" {
+		return dir
+	}
+
+	return "answers"
+}
+
+// Path returns the answers file for a day.
+func Path(dir string, year, number int) string {
+	return day.InputPath(dir, year, number)
+}
+
+// Load reads the answers for a day. A missing file is an empty record.
+func Load(dir string, year, number int) (Record, error) {
+	var r Record
+
+	path := Path(dir, year, number)
+
+	data, err := secrets.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	} else if err != nil {
+		return r, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, " ")
+		if !ok {
+			return r, &day.ParseError{File: path, Line: line, Err: errors.New("want <part> <answer>")}
+		}
+
+		switch key {
+		case "part1":
+			r.Part1 = strings.TrimSpace(value)
+		case "part2":
+			r.Part2 = strings.TrimSpace(value)
+		default:
+			return r, &day.ParseError{File: path, Line: line, Column: 1, Err: fmt.Errorf("unknown part %q", key)}
+		}
+	}
+
+	return r, nil
+}
+
+// Save writes the answers for a day.
+func Save(dir string, year, number int, r Record) error {
+	path := Path(dir, year, number)
+
+	var b strings.Builder
+	if r.Part1 != "" {
+		fmt.Fprintf(&b, "part1 %s\n", r.Part1)
+	}
+	if r.Part2 != "" {
+		fmt.Fprintf(&b, "part2 %s\n", r.Part2)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return secrets.Save(path, []byte(b.String()), 0o644)
+}
+
+// Hash returns the hashed form of an answer. The year, day and part are
+// hashed along with it, so equal answers don't hash to the same value.
+func Hash(year, number, part int, a day.Answer) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%d/%d/%d:%s", year, number, part, a))
+
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// Get returns the recorded answer for part 1 or 2.
+func (r Record) Get(part int) string {
+	if part == 1 {
+		return r.Part1
+	}
+
+	return r.Part2
+}
+
+// Set records the answer for part 1 or 2, as a hash if hashed is set.
+func (r *Record) Set(year, number, part int, a day.Answer, hashed bool) {
+	value := a.String()
+	if hashed {
+		value = Hash(year, number, part, a)
+	}
+
+	if part == 1 {
+		r.Part1 = value
+	} else {
+		r.Part2 = value
+	}
+}
+
+// Match reports whether a is the recorded answer for a part.
+func (r Record) Match(year, number, part int, a day.Answer) bool {
+	want := r.Get(part)
+	if strings.HasPrefix(want, hashPrefix) {
+		return want == Hash(year, number, part, a)
+	}
+
+	return want == a.String()
+}
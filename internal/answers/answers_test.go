@@ -0,0 +1,143 @@
+package answers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/secrets"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	var r Record
+	r.Set(2024, 7, 1, day.Int(3749), false)
+	r.Set(2024, 7, 2, day.Int(11387), true)
+
+	if err := Save(dir, 2024, 7, r); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(dir, 2024, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != r {
+		t.Errorf("want %v, got %v", r, got)
+	}
+
+	tests := []struct {
+		part   int
+		answer day.Answer
+		want   bool
+	}{
+		{1, day.Int(3749), true},
+		{1, day.Int(3748), false},
+		{2, day.Int(11387), true},
+		{2, day.String("11387"), true},
+		{2, day.Int(3749), false},
+	}
+
+	for _, test := range tests {
+		if match := got.Match(2024, 7, test.part, test.answer); match != test.want {
+			t.Errorf("part %d, answer %s: want match %t, got %t", test.part, test.answer, test.want, match)
+		}
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	r, err := Load(t.TempDir(), 2024, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != (Record{}) {
+		t.Errorf("want empty record, got %v", r)
+	}
+}
+
+func TestSaveEncrypted(t *testing.T) {
+	t.Setenv("AOC_KEY", "")
+	t.Setenv("AOC_KEY_FILE", filepath.Join(t.TempDir(), "key"))
+
+	dir := t.TempDir()
+	path := Path(dir, 2024, 7)
+
+	var r Record
+	r.Set(2024, 7, 1, day.Int(3749), false)
+
+	// without a key, answers are saved in plain text
+	if err := Save(dir, 2024, 7, r); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// with a key, they are encrypted, and the plain file is removed
+	k, _ := secrets.NewKey()
+	t.Setenv("AOC_KEY", k.String())
+
+	r.Set(2024, 7, 2, day.Int(11387), false)
+	if err := Save(dir, 2024, 7, r); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want no plain answers file, got %v", err)
+	}
+	if _, err := os.Stat(path + secrets.Ext); err != nil {
+		t.Errorf("want an encrypted answers file, got %v", err)
+	}
+
+	got, err := Load(dir, 2024, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != r {
+		t.Errorf("want %v, got %v", r, got)
+	}
+}
+
+func TestSaveLoadRun(t *testing.T) {
+	t.Setenv("AOC_KEY", "")
+	t.Setenv("AOC_KEY_FILE", filepath.Join(t.TempDir(), "key"))
+
+	dir := t.TempDir()
+	p := day.Puzzle{Year: 2024, Day: 7, Variant: "b"}
+
+	if run, err := LoadRun(dir, p); err != nil || !run.Time.IsZero() {
+		t.Fatalf("want no run before verifying, got %v, %v", run, err)
+	}
+
+	now := time.Date(2024, 12, 7, 6, 0, 0, 0, time.UTC)
+	results := []Result{
+		{Puzzle: p, Part: 1, Got: day.Int(3749), Want: Hash(2024, 7, 1, day.Int(3749)), Status: OK, Elapsed: time.Millisecond},
+		{Puzzle: p, Part: 2, Err: errors.New("boom"), Status: Failed},
+	}
+	if err := SaveRun(dir, p, now, results); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := LoadRun(dir, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the answer is hashed like the recorded one
+	want := Run{Time: now, Parts: []RunPart{
+		{Part: 1, Status: OK, Got: Hash(2024, 7, 1, day.Int(3749)), Elapsed: time.Millisecond},
+		{Part: 2, Status: Failed, Err: "boom"},
+	}}
+	if !run.Time.Equal(want.Time) || !slices.Equal(run.Parts, want.Parts) {
+		t.Errorf("want %v, got %v", want, run)
+	}
+
+	// variants are kept apart
+	if run, err := LoadRun(dir, day.Puzzle{Year: 2024, Day: 7}); err != nil || !run.Time.IsZero() {
+		t.Errorf("want no run for the base day, got %v, %v", run, err)
+	}
+}
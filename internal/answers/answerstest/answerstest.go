@@ -0,0 +1,92 @@
+// Package answerstest checks solutions against the recorded answers for the
+// real inputs from tests.
+package answerstest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"adventofcode/internal/answers"
+	"adventofcode/internal/day"
+	"adventofcode/internal/secrets"
+)
+
+// Run verifies every puzzle in a subtest of its own, and logs a table of
+// all results when any of them failed. Puzzles without an input in the
+// inputs directory are skipped, as is everything in short mode. Parts
+// without a recorded answer are solved, but can't fail.
+func Run(t *testing.T, puzzles []day.Puzzle) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping answers in short mode")
+	}
+
+	inputs, err := day.InputsDir("")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dir := Dir(t)
+	var all []answers.Result
+
+	for _, p := range puzzles {
+		t.Run(p.Name(), func(t *testing.T) {
+			if _, err := secrets.Find(day.InputPath(inputs, p.Year, p.Day)); err != nil {
+				t.Skip("no input")
+			}
+
+			results, err := answers.Verify(t.Context(), dir, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, r := range results {
+				switch r.Status {
+				case answers.Regression:
+					t.Errorf("part %d: want %s, got %s", r.Part, r.Want, r.Got)
+				case answers.Failed:
+					t.Errorf("part %d: %v", r.Part, r.Err)
+				}
+			}
+
+			all = append(all, results...)
+		})
+	}
+
+	if t.Failed() {
+		var b strings.Builder
+		answers.WriteTable(&b, all)
+		t.Log("\n" + b.String())
+	}
+}
+
+// Dir returns the answers directory: AOC_ANSWERS when it is set, and the
+// answers directory at the root of the module otherwise.
+func Dir(t testing.TB) string {
+	t.Helper()
+
+	if dir := os.Getenv("AOC_ANSWERS"); dir != "" {
+		return dir
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return filepath.Join(dir, "answers")
+		} else if !errors.Is(err, os.ErrNotExist) {
+			t.Fatal(err)
+		}
+
+		if filepath.Dir(dir) == dir {
+			t.Fatalf("no go.mod above %s", wd)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package answers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/secrets"
+)
+
+type Status int
+
+const (
+	// OK means the answer matches the recorded one.
+	OK Status = iota
+	// Regression means the answer differs from the recorded one.
+	Regression
+	// Unrecorded means there is no recorded answer to compare with.
+	Unrecorded
+	// Failed means the part returned an error.
+	Failed
+)
+
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "ok"
+	case Regression:
+		return "REGRESSION"
+	case Unrecorded:
+		return "unrecorded"
+	case Failed:
+		return "FAILED"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Status) UnmarshalText(text []byte) error {
+	for status := OK; status <= Failed; status++ {
+		if status.String() == string(text) {
+			*s = status
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown status %q", text)
+}
+
+// Result is the outcome of verifying one part of a puzzle. Elapsed is the
+// time the part took, including parsing.
+type Result struct {
+	Puzzle  day.Puzzle
+	Part    int
+	Got     day.Answer
+	Want    string
+	Status  Status
+	Err     error
+	Elapsed time.Duration
+}
+
+// Verify solves both parts of a puzzle and compares the answers with the
+// ones recorded in dir. Parts still running when ctx is done fail.
+func Verify(ctx context.Context, dir string, p day.Puzzle, opts ...day.Option) ([]Result, error) {
+	r, err := Load(dir, p.Year, p.Day)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, 2)
+
+	for part := 1; part <= 2; part++ {
+		result := Result{Puzzle: p, Part: part, Want: r.Get(part)}
+
+		start := time.Now()
+		result.Got, result.Err = p.Part(ctx, part, opts...)
+		result.Elapsed = time.Since(start)
+
+		switch {
+		case result.Err != nil:
+			result.Status = Failed
+		case result.Want == "":
+			result.Status = Unrecorded
+		case r.Match(p.Year, p.Day, part, result.Got):
+			result.Status = OK
+		default:
+			result.Status = Regression
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// WriteTable writes results as a table with a row per part.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "DAY\tPART\tSTATUS\tGOT\tWANT")
+
+	for _, r := range results {
+		got := r.Got.String()
+		if r.Err != nil {
+			got = r.Err.Error()
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", r.Puzzle.Name(), r.Part, r.Status, got, r.Want)
+	}
+
+	return tw.Flush()
+}
+
+// Run is the outcome of the last time a puzzle was verified, as kept next
+// to its answers.
+type Run struct {
+	Time  time.Time `json:"time"`
+	Parts []RunPart `json:"parts"`
+}
+
+// RunPart is the outcome of verifying a part. Got is hashed when the
+// recorded answer is, so that the file gives no more away than the
+// answers file.
+type RunPart struct {
+	Part    int           `json:"part"`
+	Status  Status        `json:"status"`
+	Got     string        `json:"got,omitempty"`
+	Err     string        `json:"error,omitempty"`
+	Elapsed time.Duration `json:"elapsed_ns"`
+}
+
+// RunPath returns the file with the last verification of a puzzle. Unlike
+// answers, the outcomes of variants are kept apart.
+func RunPath(dir string, p day.Puzzle) string {
+	return filepath.Join(dir, fmt.Sprint(p.Year), fmt.Sprintf("day%02d%s.verified.json", p.Day, p.Variant))
+}
+
+// SaveRun records results as the last verification of a puzzle, at t.
+// Like answers, the file is encrypted when there is a key.
+func SaveRun(dir string, p day.Puzzle, t time.Time, results []Result) error {
+	run := Run{Time: t}
+
+	for _, r := range results {
+		part := RunPart{Part: r.Part, Status: r.Status, Elapsed: r.Elapsed}
+		switch {
+		case r.Err != nil:
+			part.Err = r.Err.Error()
+		case strings.HasPrefix(r.Want, hashPrefix):
+			part.Got = Hash(p.Year, p.Day, r.Part, r.Got)
+		default:
+			part.Got = r.Got.String()
+		}
+
+		run.Parts = append(run.Parts, part)
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := RunPath(dir, p)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return secrets.Save(path, append(data, '\n'), 0o644)
+}
+
+// LoadRun reads the last verification of a puzzle. A puzzle that was never
+// verified has a zero Run.
+func LoadRun(dir string, p day.Puzzle) (Run, error) {
+	var run Run
+
+	path := RunPath(dir, p)
+
+	data, err := secrets.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return run, nil
+	} else if err != nil {
+		return run, err
+	}
+
+	if err := json.Unmarshal(data, &run); err != nil {
+		return run, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return run, nil
+}
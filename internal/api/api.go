@@ -0,0 +1,212 @@
+// Package api serves the solvers of all registered days over HTTP, so that
+// other tools can use them:
+//
+//	POST /v1/solve/{day}
+//	POST /v1/solve/{year}/{day}
+//
+// The body of the request is the puzzle input, and the query sets the
+// parameters of the day, e.g. /v1/solve/14?width=11&height=7. Days are named
+// as for aoc run, 7b or 2024/07b. The response is a JSON object with the
+// results of both parts, in the format of aoc run -format json:
+//
+//	{
+//	  "day": "2024/07",
+//	  "results": [
+//	    {"day": "2024/07", "part": 1, "answer": "3749", "type": "int", "elapsed_ns": 120500, "input": "..."},
+//	    {"day": "2024/07", "part": 2, "elapsed_ns": 0, "input": "...", "error": "..."}
+//	  ]
+//	}
+//
+// A part that fails has an error instead of an answer, and the status is
+// still 200; an input that doesn't parse is 422. Requests that are wrong
+// themselves get a 4xx status with an object holding only an error, and so
+// do requests that time out waiting for a free solver, with 503.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"adventofcode/internal/day"
+)
+
+const (
+	// DefaultMaxInput is the default limit on the size of an input. Real
+	// inputs are far smaller.
+	DefaultMaxInput = 1 << 20
+	// DefaultTimeout is the default limit on the time a request takes.
+	DefaultTimeout = 30 * time.Second
+)
+
+// ErrBusy is the error of requests that timed out before a solver was free.
+var ErrBusy = errors.New("all solvers are busy")
+
+// Server serves the API. Its fields may be changed before it serves the
+// first request.
+type Server struct {
+	// MaxInput is the largest input accepted, in bytes.
+	MaxInput int64
+	// Timeout limits the time a request takes, including the time it waits
+	// for a solver. Parts still running then fail with ErrTimeout. Parts of
+	// days that can't be cancelled keep running until they return, and hold
+	// their solver until then.
+	Timeout time.Duration
+
+	mux   *http.ServeMux
+	slots chan struct{}
+}
+
+// New returns a server that solves at most workers requests at the same
+// time, or runtime.GOMAXPROCS when it is zero. Further requests wait for
+// one of them to finish.
+func New(workers int) *Server {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	s := &Server{
+		MaxInput: DefaultMaxInput,
+		Timeout:  DefaultTimeout,
+		slots:    make(chan struct{}, workers),
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("POST /v1/solve/{day}", s.solve)
+	s.mux.HandleFunc("POST /v1/solve/{year}/{day}", s.solve)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Response is the body of a successful request.
+type Response struct {
+	Day     string       `json:"day"`
+	Results []day.Result `json:"results"`
+}
+
+// errorResponse is the body of a failed request.
+type errorResponse struct {
+	Err string `json:"error"`
+}
+
+func (s *Server) solve(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("day")
+	if year := r.PathValue("year"); year != "" {
+		name = year + "/" + name
+	}
+
+	p, ok := day.Lookup(name)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown day %q", name))
+		return
+	}
+
+	opts, err := params(p, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.MaxInput))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("input larger than %d bytes", tooLarge.Limit))
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(input) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("empty input, send it as the body"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeoutCause(r.Context(), s.Timeout, fmt.Errorf("%w after %s", day.ErrTimeout, s.Timeout))
+	defer cancel()
+
+	var running sync.WaitGroup
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() {
+			go func() {
+				running.Wait()
+				<-s.slots
+			}()
+		}()
+	case <-ctx.Done():
+		writeError(w, http.StatusServiceUnavailable, ErrBusy)
+		return
+	}
+
+	// inputs from clients are parsed for every part rather than cached, so
+	// that the cache doesn't grow with every request
+	opts = append(opts, day.WithReader(bytes.NewReader(input)), day.WithoutCache())
+
+	resp := Response{Day: p.Name()}
+	day.Scheduler{Workers: 2, Running: &running}.Run(ctx, day.Tasks([]day.Puzzle{p}), func(r day.Result) {
+		resp.Results = append(resp.Results, r)
+	}, opts...)
+
+	status := http.StatusOK
+	if !slices.ContainsFunc(resp.Results, func(r day.Result) bool {
+		var parseErr *day.ParseError
+		return !errors.As(r.Err, &parseErr)
+	}) {
+		status = http.StatusUnprocessableEntity
+	}
+
+	writeJSON(w, status, resp)
+}
+
+// params returns options setting the parameters given in the query of r,
+// which must all be parameters of p, within their bounds.
+func params(p day.Puzzle, r *http.Request) ([]day.Option, error) {
+	var opts []day.Option
+
+	for name, values := range r.URL.Query() {
+		i := slices.IndexFunc(p.Params, func(param day.Param) bool { return param.Name == name })
+		if i == -1 {
+			return nil, fmt.Errorf("day %s has no parameter %q", p.Name(), name)
+		}
+
+		v, err := strconv.Atoi(values[len(values)-1])
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: want a number, got %q", name, values[len(values)-1])
+		}
+		if err := p.Params[i].Check(v); err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, day.WithParam(name, v))
+	}
+
+	return opts, nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("api: %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"adventofcode/internal/day"
+	_ "adventofcode/internal/days/2024/day07"
+	_ "adventofcode/internal/days/2024/day11"
+)
+
+var factor = day.NewParam("factor", 2, "factor of part 2").Between(1, 100)
+
+// sum adds up a number per line for part 1, and multiplies the sum by
+// factor for part 2.
+type sum struct {
+	numbers []int
+	factor  int
+}
+
+func newSum(opts ...day.Option) (sum, error) {
+	input, err := day.NewDayInput(2015, 5, nil, opts...)
+	if err != nil {
+		return sum{}, err
+	}
+
+	numbers, err := day.Load(input.Expect(day.Shape{{Name: "numbers", Pattern: `\d+`}}), func(d day.DayInput) ([]int, error) {
+		lines, err := d.ReadLines()
+		if err != nil {
+			return nil, err
+		}
+
+		var numbers []int
+		for _, line := range lines {
+			n, err := strconv.Atoi(line)
+			if err != nil {
+				return nil, err
+			}
+			numbers = append(numbers, n)
+		}
+
+		return numbers, nil
+	})
+
+	return sum{numbers, input.Param(factor)}, err
+}
+
+func (s sum) Part1() (day.Answer, error) {
+	total := 0
+	for _, n := range s.numbers {
+		total += n
+	}
+
+	return day.Int(total), nil
+}
+
+func (s sum) Part2() (day.Answer, error) {
+	a, _ := s.Part1()
+	n, _ := strconv.Atoi(a.String())
+
+	return day.Int(n * s.factor), nil
+}
+
+// started is told when a part of the slow day starts, if it has room.
+var started chan struct{}
+
+// slow never finishes a part before its context is done.
+type slow struct{}
+
+func (slow) Part1() (day.Answer, error) { return day.Answer{}, nil }
+func (slow) Part2() (day.Answer, error) { return day.Answer{}, nil }
+
+func (slow) Part1Context(ctx context.Context) (day.Answer, error) {
+	select {
+	case started <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return day.Answer{}, ctx.Err()
+}
+
+func (s slow) Part2Context(ctx context.Context) (day.Answer, error) {
+	return s.Part1Context(ctx)
+}
+
+// release is closed to let the parts of the stuck day return.
+var release chan struct{}
+
+// stuck can't be cancelled, and blocks in both parts until release is
+// closed.
+type stuck struct{}
+
+func (stuck) Part1() (day.Answer, error) { <-release; return day.Int(1), nil }
+func (stuck) Part2() (day.Answer, error) { <-release; return day.Int(2), nil }
+
+func init() {
+	day.Register(2015, 5, "", newSum, factor)
+	day.Register(2015, 6, "", func(...day.Option) (slow, error) { return slow{}, nil })
+	day.Register(2015, 7, "", func(...day.Option) (stuck, error) { return stuck{}, nil })
+}
+
+type result struct {
+	Status int
+	Day    string `json:"day"`
+	Err    string `json:"error"`
+	// Results are kept as maps, to check the JSON as other tools see it.
+	Results []map[string]any `json:"results"`
+}
+
+func post(t *testing.T, h http.Handler, ctx context.Context, path, input string) result {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequestWithContext(ctx, "POST", path, strings.NewReader(input)))
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s: want JSON, got %q", path, ct)
+	}
+
+	// not Fatal, as TestBusy posts from another goroutine
+	r := result{Status: w.Code}
+	if err := json.Unmarshal(w.Body.Bytes(), &r); err != nil {
+		t.Errorf("%s: %v: %s", path, err, w.Body)
+	}
+
+	return r
+}
+
+func answers(r result) string {
+	var parts []string
+	for _, result := range r.Results {
+		if err, ok := result["error"]; ok {
+			parts = append(parts, fmt.Sprint("error: ", err))
+		} else {
+			parts = append(parts, fmt.Sprint(result["answer"]))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func TestSolve(t *testing.T) {
+	example, err := os.ReadFile("../days/2024/day07/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, path, input string
+		status            int
+		want              string
+	}{
+		{"day", "/v1/solve/2015/5", "1\n2\n3\n", http.StatusOK, "6 12"},
+		{"padded day", "/v1/solve/2015/05", "1\n2\n3\n", http.StatusOK, "6 12"},
+		{"param", "/v1/solve/2015/5?factor=10", "1\n2\n3\n", http.StatusOK, "6 60"},
+		{"crlf", "/v1/solve/2015/5", "1\r\n2\r\n", http.StatusOK, "3 6"},
+		{"registered day", "/v1/solve/2024/7", string(example), http.StatusOK, "3749 11387"},
+		{"latest year", "/v1/solve/7", string(example), http.StatusOK, "3749 11387"},
+		{"parse error", "/v1/solve/2015/5", "1\nx\n", http.StatusUnprocessableEntity, `error: reader line 2: bad line "x" in the numbers, want \d+ error: reader line 2: bad line "x" in the numbers, want \d+`},
+	}
+
+	s := New(0)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := post(t, s, t.Context(), tt.path, tt.input)
+
+			if r.Status != tt.status || r.Err != "" {
+				t.Fatalf("want status %d, got %d: %s", tt.status, r.Status, r.Err)
+			}
+			if got := answers(r); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+			if len(r.Results) != 2 || r.Results[0]["part"] != 1.0 || r.Results[1]["part"] != 2.0 {
+				t.Errorf("want results for parts 1 and 2 in order, got %v", r.Results)
+			}
+		})
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	s := New(0)
+	s.MaxInput = 8
+
+	tests := []struct {
+		method, path, input string
+		status              int
+		err                 string
+	}{
+		{"POST", "/v1/solve/2015/4", "1\n", http.StatusNotFound, `unknown day "2015/4"`},
+		{"POST", "/v1/solve/x", "1\n", http.StatusNotFound, `unknown day "x"`},
+		{"POST", "/v1/solve/2015/5?size=3", "1\n", http.StatusBadRequest, `day 2015/05 has no parameter "size"`},
+		{"POST", "/v1/solve/2015/5?factor=x", "1\n", http.StatusBadRequest, `parameter factor: want a number, got "x"`},
+		{"POST", "/v1/solve/2015/5?factor=0", "1\n", http.StatusBadRequest, "parameter factor: want at least 1, got 0"},
+		// would hold a solver for hours
+		{"POST", "/v1/solve/2024/11?blinks2=100000000", "1\n", http.StatusBadRequest, "parameter blinks2: want at most 90, got 100000000"},
+		{"POST", "/v1/solve/2015/5", "", http.StatusBadRequest, "empty input, send it as the body"},
+		{"POST", "/v1/solve/2015/5", "1\n2\n3\n4\n5\n", http.StatusRequestEntityTooLarge, "input larger than 8 bytes"},
+	}
+
+	for _, tt := range tests {
+		r := post(t, s, t.Context(), tt.path, tt.input)
+
+		if r.Status != tt.status || r.Err != tt.err {
+			t.Errorf("%s: want %d %q, got %d %q", tt.path, tt.status, tt.err, r.Status, r.Err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/v1/solve/2015/5", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: want status 405, got %d", w.Code)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	s := New(0)
+	s.Timeout = 20 * time.Millisecond
+
+	r := post(t, s, t.Context(), "/v1/solve/2015/6", "1\n")
+	if r.Status != http.StatusOK {
+		t.Fatalf("want status 200, got %d: %s", r.Status, r.Err)
+	}
+
+	want := fmt.Sprintf("error: %v after 20ms", day.ErrTimeout)
+	if got := answers(r); got != want+" "+want {
+		t.Errorf("want both parts to time out, got %s", got)
+	}
+}
+
+func TestBusy(t *testing.T) {
+	s := New(1)
+	s.Timeout = time.Second
+	started = make(chan struct{}, 2)
+
+	// the slow day holds the only solver until it is cancelled
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan result)
+	go func() {
+		done <- post(t, s, ctx, "/v1/solve/2015/6", "1\n")
+	}()
+	<-started
+
+	waiting, stop := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer stop()
+	if r := post(t, s, waiting, "/v1/solve/2015/5", "1\n"); r.Status != http.StatusServiceUnavailable || r.Err != ErrBusy.Error() {
+		t.Errorf("want %d %q while the solver is busy, got %d %q", http.StatusServiceUnavailable, ErrBusy, r.Status, r.Err)
+	}
+
+	cancel()
+	<-done
+
+	if r := post(t, s, t.Context(), "/v1/solve/2015/5", "1\n"); r.Status != http.StatusOK {
+		t.Errorf("want status 200 once the solver is free, got %d: %s", r.Status, r.Err)
+	}
+}
+
+func TestStuck(t *testing.T) {
+	s := New(1)
+	s.Timeout = 50 * time.Millisecond
+	release = make(chan struct{})
+
+	if r := post(t, s, t.Context(), "/v1/solve/2015/7", "1\n"); r.Status != http.StatusOK || !strings.Contains(answers(r), day.ErrTimeout.Error()) {
+		t.Fatalf("want both parts to time out, got %d %s", r.Status, answers(r))
+	}
+
+	// the parts that timed out still run, and hold the only solver
+	if r := post(t, s, t.Context(), "/v1/solve/2015/5", "1\n"); r.Status != http.StatusServiceUnavailable {
+		t.Errorf("want status %d while the stuck parts run, got %d: %s", http.StatusServiceUnavailable, r.Status, r.Err)
+	}
+
+	close(release)
+
+	if r := post(t, s, t.Context(), "/v1/solve/2015/5", "1\n"); r.Status != http.StatusOK {
+		t.Errorf("want status 200 once the stuck parts returned, got %d: %s", r.Status, r.Err)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	server := httptest.NewServer(New(4))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+
+	for i := range 32 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			input := strings.Repeat(strconv.Itoa(i)+"\n", i+1)
+			resp, err := http.Post(server.URL+"/v1/solve/2015/5", "text/plain", strings.NewReader(input))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer resp.Body.Close()
+
+			r := result{Status: resp.StatusCode}
+			if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+				t.Error(err)
+				return
+			}
+
+			if want := fmt.Sprintf("%d %d", i*(i+1), 2*i*(i+1)); r.Status != http.StatusOK || answers(r) != want {
+				t.Errorf("request %d: want %s, got %d %s", i, want, r.Status, answers(r))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
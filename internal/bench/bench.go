@@ -0,0 +1,225 @@
+// Package bench times the solutions. Parsing, which a day does when it is
+// created, and both parts are timed separately, so that a slow parser
+// doesn't hide in the timings of the parts.
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"adventofcode/internal/day"
+)
+
+// Phases are the steps of solving a puzzle that are timed.
+var Phases = []string{"parse", "part1", "part2"}
+
+// Result holds the timings of one phase of a puzzle over all runs. Allocs
+// and Bytes are per run. Input is the hash of the input, and Samples holds
+// the time of every run, for comparing against other results.
+type Result struct {
+	Day     string          `json:"day"`
+	Phase   string          `json:"phase"`
+	Input   string          `json:"input,omitempty"`
+	Runs    int             `json:"runs"`
+	Min     time.Duration   `json:"min_ns"`
+	Median  time.Duration   `json:"median_ns"`
+	P95     time.Duration   `json:"p95_ns"`
+	Allocs  uint64          `json:"allocs"`
+	Bytes   uint64          `json:"bytes"`
+	Samples []time.Duration `json:"samples_ns,omitempty"`
+	Err     string          `json:"error,omitempty"`
+}
+
+type sample struct {
+	elapsed       time.Duration
+	allocs, bytes uint64
+}
+
+// measure runs fn once, and reports how long it took and what it allocated.
+func measure(fn func() error) (sample, error) {
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	err := fn()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return sample{elapsed, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc}, err
+}
+
+// solve solves part n of d, failing with day.ErrTimeout when it takes
+// longer than timeout, unless timeout is zero.
+func solve(ctx context.Context, d day.Day, n int, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w after %s", day.ErrTimeout, timeout))
+		defer cancel()
+	}
+
+	_, err := day.SolvePart(ctx, d, n)
+	if err != nil && ctx.Err() != nil {
+		err = context.Cause(ctx)
+	}
+
+	return err
+}
+
+// Run solves a puzzle n times, and returns the timings of every phase. The
+// input is parsed on every run, bypassing the cache of parsed inputs. A
+// part that takes longer than timeout fails, unless timeout is zero, and
+// so do the parts left once ctx is done. A phase that fails stops the
+// runs, and is reported in the result's Err, along with the phases that
+// depend on it.
+func Run(ctx context.Context, p day.Puzzle, n int, timeout time.Duration, opts ...day.Option) []Result {
+	opts = append(slices.Clone(opts), day.WithoutCache())
+
+	samples := make([][]sample, len(Phases))
+	failedPhase := len(Phases)
+	var failed error
+
+	for range n {
+		var d day.Day
+
+		phases := []func() error{
+			func() (err error) {
+				d, err = p.New(opts...)
+				return err
+			},
+			func() error {
+				return solve(ctx, d, 1, timeout)
+			},
+			func() error {
+				return solve(ctx, d, 2, timeout)
+			},
+		}
+
+		for i, phase := range phases {
+			s, err := measure(func() (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("%w: %v", day.ErrPanic, r)
+					}
+				}()
+
+				return phase()
+			})
+			if err != nil {
+				failed = fmt.Errorf("%s: %w", Phases[i], err)
+				failedPhase = i
+				break
+			}
+
+			samples[i] = append(samples[i], s)
+		}
+
+		if failed != nil {
+			break
+		}
+	}
+
+	results := make([]Result, len(Phases))
+
+	for i, phase := range Phases {
+		results[i] = summarize(samples[i])
+		results[i].Day = p.Name()
+		results[i].Phase = phase
+
+		if i >= failedPhase {
+			results[i].Err = failed.Error()
+		}
+	}
+
+	return results
+}
+
+func summarize(samples []sample) Result {
+	r := Result{Runs: len(samples)}
+	if len(samples) == 0 {
+		return r
+	}
+
+	r.Samples = make([]time.Duration, len(samples))
+
+	for i, s := range samples {
+		r.Samples[i] = s.elapsed
+		r.Allocs += s.allocs
+		r.Bytes += s.bytes
+	}
+
+	elapsed := slices.Sorted(slices.Values(r.Samples))
+
+	r.Min = elapsed[0]
+	r.Median = median(elapsed)
+	r.P95 = percentile(elapsed, 95)
+	r.Allocs /= uint64(len(samples))
+	r.Bytes /= uint64(len(samples))
+
+	return r
+}
+
+// median returns the median of sorted durations.
+func median(sorted []time.Duration) time.Duration {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// percentile returns the smallest of the sorted durations that is at least
+// as large as p percent of them.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p + 99) / 100
+
+	return sorted[max(i-1, 0)]
+}
+
+// WriteTable writes results as a table with a row per phase.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tw, "DAY\tPHASE\tRUNS\tMIN\tMEDIAN\tP95\tALLOCS\tBYTES\t")
+
+	for _, r := range results {
+		if r.Err != "" {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t\t\t\t\t\t%s\n", r.Day, r.Phase, r.Runs, r.Err)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t\n",
+			r.Day, r.Phase, r.Runs, round(r.Min), round(r.Median), round(r.P95), r.Allocs, r.Bytes)
+	}
+
+	return tw.Flush()
+}
+
+// WriteJSON writes results as a JSON array, with durations in nanoseconds.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(results)
+}
+
+// round drops the digits of a duration that are only noise.
+func round(d time.Duration) time.Duration {
+	switch {
+	case d > time.Second:
+		return d.Round(time.Millisecond)
+	case d > time.Millisecond:
+		return d.Round(time.Microsecond)
+	default:
+		return d
+	}
+}
@@ -0,0 +1,89 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"adventofcode/internal/day"
+)
+
+func TestSummarize(t *testing.T) {
+	var samples []sample
+	for _, ms := range []int{7, 1, 3, 20, 5, 2, 4, 6, 8, 9} {
+		samples = append(samples, sample{time.Duration(ms) * time.Millisecond, 10, 100})
+	}
+
+	r := summarize(samples)
+
+	want := Result{Runs: 10, Min: time.Millisecond, Median: 5500 * time.Microsecond, P95: 20 * time.Millisecond, Allocs: 10, Bytes: 100}
+	r.Samples = nil
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("want %+v, got %+v", want, r)
+	}
+}
+
+type fake struct {
+	err error
+}
+
+func (f fake) Part1() (day.Answer, error) {
+	return day.Int(1), nil
+}
+
+func (f fake) Part2() (day.Answer, error) {
+	return day.Answer{}, f.err
+}
+
+func TestRun(t *testing.T) {
+	p := day.Puzzle{Year: 2024, Day: 1, New: func(...day.Option) (day.Day, error) { return fake{}, nil }}
+
+	results := Run(t.Context(), p, 3, 0)
+	if len(results) != len(Phases) {
+		t.Fatalf("want %d results, got %d", len(Phases), len(results))
+	}
+
+	for i, r := range results {
+		if r.Day != "2024/01" || r.Phase != Phases[i] || r.Runs != 3 || r.Err != "" {
+			t.Errorf("want 3 runs of 01 %s, got %+v", Phases[i], r)
+		}
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	p := day.Puzzle{Year: 2024, Day: 2, New: func(...day.Option) (day.Day, error) { return fake{errors.New("broken")}, nil }}
+
+	results := Run(t.Context(), p, 3, 0)
+
+	if results[1].Runs != 1 || results[1].Err != "" {
+		t.Errorf("want a single run of part1, got %+v", results[1])
+	}
+	if results[2].Runs != 0 || !strings.Contains(results[2].Err, "broken") {
+		t.Errorf("want part2 to fail, got %+v", results[2])
+	}
+}
+
+// slow never finishes part 1 before its context is done.
+type slow struct{ fake }
+
+func (slow) Part1Context(ctx context.Context) (day.Answer, error) {
+	<-ctx.Done()
+	return day.Answer{}, ctx.Err()
+}
+
+func (s slow) Part2Context(ctx context.Context) (day.Answer, error) {
+	return s.Part2()
+}
+
+func TestRunTimeout(t *testing.T) {
+	p := day.Puzzle{Year: 2024, Day: 3, New: func(...day.Option) (day.Day, error) { return slow{}, nil }}
+
+	results := Run(t.Context(), p, 3, 10*time.Millisecond)
+
+	if results[1].Runs != 0 || !strings.Contains(results[1].Err, day.ErrTimeout.Error()) {
+		t.Errorf("want part1 to time out, got %+v", results[1])
+	}
+}
@@ -0,0 +1,254 @@
+package bench
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Alpha is the significance level below which a difference between two
+// sets of timings is taken to be real.
+const Alpha = 0.05
+
+// Change says how the timings of a phase changed between two runs.
+type Change int
+
+const (
+	// Same means there is no significant difference.
+	Same Change = iota
+	Faster
+	Slower
+	// Incomparable means the phase is missing from one of the runs, or
+	// the runs used different inputs.
+	Incomparable
+)
+
+func (c Change) String() string {
+	switch c {
+	case Same:
+		return "~"
+	case Faster:
+		return "faster"
+	case Slower:
+		return "SLOWER"
+	case Incomparable:
+		return "n/a"
+	default:
+		return fmt.Sprintf("Change(%d)", int(c))
+	}
+}
+
+// Comparison holds the difference between the timings of a phase of a day
+// in two runs.
+type Comparison struct {
+	Day, Phase string
+	Old, New   time.Duration
+	// Delta is the relative change of the median.
+	Delta  float64
+	P      float64
+	Change Change
+	Note   string
+}
+
+// Compare compares the results of two runs per day and phase, with a
+// Mann-Whitney U test on the samples, like benchstat does.
+func Compare(old, new Record) []Comparison {
+	type key struct{ day, phase string }
+
+	olds := make(map[key]Result)
+	for _, r := range old.Results {
+		olds[key{r.Day, r.Phase}] = r
+	}
+
+	var result []Comparison
+
+	for _, n := range new.Results {
+		c := Comparison{Day: n.Day, Phase: n.Phase, New: n.Median, P: 1, Change: Incomparable}
+
+		o, ok := olds[key{n.Day, n.Phase}]
+
+		switch {
+		case !ok:
+			c.Note = "not in old run"
+		case o.Err != "" || n.Err != "":
+			c.Note = "failed"
+		case o.Input != n.Input:
+			c.Note = "input changed"
+		case len(o.Samples) == 0 || len(n.Samples) == 0:
+			c.Note = "no samples"
+		case o.Median == 0:
+			// too fast for the clock, there is no relative change
+			c.Note = "old median is zero"
+		default:
+			c.Old = o.Median
+			c.Delta = float64(n.Median-o.Median) / float64(o.Median)
+			c.P = MannWhitney(o.Samples, n.Samples)
+			c.Change = Same
+
+			if c.P < Alpha {
+				if n.Median > o.Median {
+					c.Change = Slower
+				} else if n.Median < o.Median {
+					c.Change = Faster
+				}
+			}
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// MannWhitney returns the two-sided p-value of the Mann-Whitney U test for
+// the samples x and y coming from the same distribution. It is exact for
+// small samples without ties, and uses the normal approximation otherwise.
+func MannWhitney(x, y []time.Duration) float64 {
+	m, n := len(x), len(y)
+	if m == 0 || n == 0 {
+		return 1
+	}
+
+	// rank all samples together, giving ties their average rank
+	type value struct {
+		d     time.Duration
+		fromX bool
+	}
+
+	all := make([]value, 0, m+n)
+	for _, d := range x {
+		all = append(all, value{d, true})
+	}
+	for _, d := range y {
+		all = append(all, value{d, false})
+	}
+	slices.SortFunc(all, func(a, b value) int {
+		return cmp.Compare(a.d, b.d)
+	})
+
+	rankSumX := 0.0
+	ties := 0.0
+
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].d == all[i].d {
+			j++
+		}
+
+		rank := float64(i+j+1) / 2
+		for _, v := range all[i:j] {
+			if v.fromX {
+				rankSumX += rank
+			}
+		}
+
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	u := rankSumX - float64(m*(m+1))/2
+
+	if ties == 0 && m <= 50 && n <= 50 {
+		return exactP(m, n, int(u))
+	}
+
+	mu := float64(m*n) / 2
+	total := float64(m + n)
+	sigma := math.Sqrt(float64(m*n) / 12 * ((total + 1) - ties/(total*(total-1))))
+	if sigma == 0 {
+		return 1
+	}
+
+	// continuity correction towards the mean
+	z := math.Max(math.Abs(u-mu)-0.5, 0) / sigma
+
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactP returns the two-sided p-value of u from the exact distribution of
+// the U statistic for samples of sizes m and n.
+func exactP(m, n, u int) float64 {
+	// counts[i][j][k] is the number of orderings of i values from x and
+	// j from y with a U statistic of k; only the j dimension is kept.
+	counts := make([][]float64, n+1)
+	for j := range counts {
+		counts[j] = make([]float64, m*n+1)
+		counts[j][0] = 1
+	}
+
+	for i := 1; i <= m; i++ {
+		next := make([][]float64, n+1)
+		next[0] = make([]float64, m*n+1)
+		next[0][0] = 1
+
+		for j := 1; j <= n; j++ {
+			next[j] = make([]float64, m*n+1)
+
+			for k := range next[j] {
+				// the largest value is from y, adding nothing to U, or
+				// from x, being larger than all j values from y
+				next[j][k] = next[j-1][k]
+				if k >= j {
+					next[j][k] += counts[j][k-j]
+				}
+			}
+		}
+
+		counts = next
+	}
+
+	dist := counts[n]
+	total, below, above := 0.0, 0.0, 0.0
+
+	for k, c := range dist {
+		total += c
+		if k <= u {
+			below += c
+		}
+		if k >= u {
+			above += c
+		}
+	}
+
+	return math.Min(1, 2*math.Min(below, above)/total)
+}
+
+// WriteComparison writes comparisons as a table with a row per phase.
+func WriteComparison(w io.Writer, old, new Record, comparisons []Comparison) error {
+	fmt.Fprintf(w, "old: %s %s\n", short(old.Commit), old.Time.Format(time.DateTime))
+	fmt.Fprintf(w, "new: %s %s\n\n", short(new.Commit), new.Time.Format(time.DateTime))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tw, "DAY\tPHASE\tOLD\tNEW\tDELTA\tP\t")
+
+	// the change is in a last, left aligned column
+	for _, c := range comparisons {
+		if c.Change == Incomparable {
+			fmt.Fprintf(tw, "%s\t%s\t\t%s\t\t\t  %s (%s)\n", c.Day, c.Phase, round(c.New), c.Change, c.Note)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%+.1f%%\t%.3f\t  %s\n",
+			c.Day, c.Phase, round(c.Old), round(c.New), 100*c.Delta, c.P, c.Change)
+	}
+
+	return tw.Flush()
+}
+
+func short(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+
+	if commit == "" {
+		return "(no commit)"
+	}
+
+	return commit
+}
@@ -0,0 +1,79 @@
+package bench
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func durations(ms ...int) []time.Duration {
+	result := make([]time.Duration, len(ms))
+	for i, m := range ms {
+		result[i] = time.Duration(m) * time.Millisecond
+	}
+	return result
+}
+
+func TestMannWhitney(t *testing.T) {
+	tests := []struct {
+		x, y []time.Duration
+		want float64
+	}{
+		// all of x below all of y: 1 of 252 orderings, on either side
+		{durations(1, 2, 3, 4, 5), durations(6, 7, 8, 9, 10), 2.0 / 252},
+		{durations(6, 7, 8, 9, 10), durations(1, 2, 3, 4, 5), 2.0 / 252},
+		{durations(1, 3, 5, 7, 9), durations(2, 4, 6, 8, 10), 174.0 / 252},
+		// three samples each can never be significant
+		{durations(1, 2, 3), durations(4, 5, 6), 0.1},
+		// ties use the normal approximation
+		{durations(1, 1, 1, 1, 1), durations(1, 1, 1, 1, 1), 1},
+	}
+
+	for _, test := range tests {
+		if got := MannWhitney(test.x, test.y); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("%v vs %v: want p %.4f, got %.4f", test.x, test.y, test.want, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	result := func(day, phase, input string, samples []time.Duration) Result {
+		r := summarize(nil)
+		r.Day, r.Phase, r.Input = day, phase, input
+		r.Samples = samples
+		r.Median = median(samples)
+		return r
+	}
+
+	old := Record{Results: []Result{
+		result("06", "part2", "a", durations(10, 11, 12, 13, 14)),
+		result("22", "part1", "b", durations(10, 11, 12, 13, 14)),
+		result("22", "part2", "b", durations(10, 11, 12, 13, 14)),
+		result("23", "part1", "c", durations(10, 11, 12, 13, 14)),
+		result("25", "part1", "e", durations(0, 0, 0, 0, 0)),
+	}}
+	new := Record{Results: []Result{
+		result("06", "part2", "a", durations(20, 21, 22, 23, 24)),
+		result("22", "part1", "b", durations(5, 6, 7, 8, 9)),
+		result("22", "part2", "b", durations(10, 12, 11, 14, 13)),
+		result("23", "part1", "changed", durations(10, 11, 12, 13, 14)),
+		result("24", "part1", "d", durations(10, 11, 12, 13, 14)),
+		result("25", "part1", "e", durations(1, 1, 1, 1, 1)),
+	}}
+
+	want := []Change{Slower, Faster, Same, Incomparable, Incomparable, Incomparable}
+
+	got := Compare(old, new)
+	if len(got) != len(want) {
+		t.Fatalf("want %d comparisons, got %d", len(want), len(got))
+	}
+
+	for i, c := range got {
+		if c.Change != want[i] {
+			t.Errorf("%s %s: want %s, got %s", c.Day, c.Phase, want[i], c.Change)
+		}
+		if math.IsNaN(c.Delta) || math.IsInf(c.Delta, 0) {
+			t.Errorf("%s %s: want a finite delta, got %f", c.Day, c.Phase, c.Delta)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package bench
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Commit returns the hash of the commit checked out in the git repository
+// that holds dir. It reads .git directly, so it doesn't need git to be
+// installed.
+func Commit(dir string) (string, error) {
+	gitDir, err := findGitDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", err
+	}
+
+	ref, ok := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: ")
+	if !ok {
+		// a detached head holds the hash itself
+		return ref, nil
+	}
+
+	// refs are shared by all worktrees, and live in the common directory
+	commonDir := gitDir
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = resolve(gitDir, strings.TrimSpace(string(common)))
+	}
+
+	for _, d := range []string{gitDir, commonDir} {
+		if hash, err := os.ReadFile(filepath.Join(d, filepath.FromSlash(ref))); err == nil {
+			return strings.TrimSpace(string(hash)), nil
+		}
+	}
+
+	return packedRef(commonDir, ref)
+}
+
+// findGitDir looks for .git in dir and its parents. In a worktree .git is a
+// file pointing at the actual git directory.
+func findGitDir(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, ".git")
+
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && info.IsDir():
+			return path, nil
+		case err == nil:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+			if !ok {
+				return "", fmt.Errorf("%s: no gitdir", path)
+			}
+
+			return resolve(dir, gitDir), nil
+		case !errors.Is(err, os.ErrNotExist):
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not in a git repository")
+		}
+		dir = parent
+	}
+}
+
+func resolve(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
+
+// packedRef looks up a ref in packed-refs, where git moves refs when it
+// packs them.
+func packedRef(gitDir, ref string) (string, error) {
+	path := filepath.Join(gitDir, "packed-refs")
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("ref %s not found", ref)
+	} else if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		hash, name, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == ref {
+			return hash, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	return "", fmt.Errorf("ref %s not found", ref)
+}
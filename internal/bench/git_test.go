@@ -0,0 +1,73 @@
+package bench
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCommit(t *testing.T) {
+	const (
+		loose  = "1111111111111111111111111111111111111111"
+		packed = "2222222222222222222222222222222222222222"
+		detach = "3333333333333333333333333333333333333333"
+	)
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"loose ref", map[string]string{
+			".git/HEAD":            "ref: refs/heads/main\n",
+			".git/refs/heads/main": loose + "\n",
+		}, loose},
+		{"packed ref", map[string]string{
+			".git/HEAD":        "ref: refs/heads/main\n",
+			".git/packed-refs": "# pack-refs with: peeled fully-peeled sorted\n" + packed + " refs/heads/main\n",
+		}, packed},
+		{"detached head", map[string]string{
+			".git/HEAD": detach + "\n",
+		}, detach},
+		{"worktree", map[string]string{
+			".git":                             "gitdir: repo/.git/worktrees/wt\n",
+			"repo/.git/worktrees/wt/HEAD":      "ref: refs/heads/feature\n",
+			"repo/.git/worktrees/wt/commondir": "../..\n",
+			"repo/.git/refs/heads/feature":     loose + "\n",
+		}, loose},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, test.files)
+
+			sub := filepath.Join(dir, "internal", "days")
+			if err := os.MkdirAll(sub, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Commit(sub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
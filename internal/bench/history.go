@@ -0,0 +1,130 @@
+package bench
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"adventofcode/internal/day"
+)
+
+// Record is one benchmark run of a number of days, saved in the history.
+type Record struct {
+	Commit  string    `json:"commit"`
+	Time    time.Time `json:"time"`
+	Results []Result  `json:"results"`
+}
+
+// InputHash returns the xxhash of the input that a puzzle reads with opts,
+// so that timings are only compared when they were made with the same
+// input. It is the hash that run -format json gives, see day.Result.
+func InputHash(p day.Puzzle, opts ...day.Option) (string, error) {
+	input, err := day.NewDayInput(p.Year, p.Day, nil, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return input.InputHash()
+}
+
+// LoadHistory reads all records from the history file at path, oldest
+// first. A missing file is an empty history.
+func LoadHistory(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var result []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, &day.ParseError{File: path, Line: line, Err: err}
+		}
+
+		// records from before days had years are all of 2024
+		for i := range r.Results {
+			if !strings.Contains(r.Results[i].Day, "/") {
+				r.Results[i].Day = "2024/" + r.Results[i].Day
+			}
+		}
+
+		result = append(result, r)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return result, nil
+}
+
+// AppendHistory adds a record to the end of the history file at path.
+func AppendHistory(path string, r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Find returns the latest record in history whose commit starts with ref.
+// A ref of last picks the last record instead, and last-n the record n
+// places before it.
+func Find(history []Record, ref string) (Record, error) {
+	if rest, ok := strings.CutPrefix(ref, "last"); ok {
+		back := 0
+		if rest != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(rest, "-"))
+			if err != nil || n < 0 || rest[0] != '-' {
+				return Record{}, fmt.Errorf("bad ref %q", ref)
+			}
+			back = n
+		}
+
+		if back >= len(history) {
+			return Record{}, fmt.Errorf("only %d runs in history", len(history))
+		}
+
+		return history[len(history)-1-back], nil
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		if ref != "" && strings.HasPrefix(history[i].Commit, ref) {
+			return history[i], nil
+		}
+	}
+
+	return Record{}, fmt.Errorf("no run for commit %q", ref)
+}
@@ -0,0 +1,89 @@
+package bench
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"adventofcode/internal/day"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.jsonl")
+
+	for _, commit := range []string{"aaaa", "bbbb", "aaaa"} {
+		r := Record{Commit: commit, Results: []Result{{Day: "01", Phase: "part1", Samples: durations(1, 2)}}}
+		if err := AppendHistory(path, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("want 3 records, got %d", len(history))
+	}
+	if got := history[0].Results[0].Samples; len(got) != 2 || got[1] != durations(2)[0] {
+		t.Errorf("want samples to survive a round trip, got %v", got)
+	}
+	if got := history[0].Results[0].Day; got != "2024/01" {
+		t.Errorf("want days without a year to be of 2024, got %s", got)
+	}
+
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{"last", 2},
+		{"last-1", 1},
+		{"last-2", 0},
+		{"aa", 2},
+		{"bbbb", 1},
+	}
+
+	for _, test := range tests {
+		got, err := Find(history, test.ref)
+		if err != nil {
+			t.Errorf("%s: %v", test.ref, err)
+			continue
+		}
+		if got.Commit != history[test.want].Commit {
+			t.Errorf("%s: want commit %s, got %s", test.ref, history[test.want].Commit, got.Commit)
+		}
+	}
+
+	for _, ref := range []string{"last-3", "last1", "cccc", ""} {
+		if _, err := Find(history, ref); err == nil {
+			t.Errorf("%s: want error, got nil", ref)
+		}
+	}
+}
+
+func TestInputHash(t *testing.T) {
+	// a day that parses nothing, for the hash of its input
+	p := day.Puzzle{Year: 2024, Day: 4, New: func(opts ...day.Option) (day.Day, error) {
+		input, err := day.NewDayInput(2024, 4, nil, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return day.Load(input, func(day.DayInput) (day.Day, error) { return fake{}, nil })
+	}}
+
+	var solved string
+	day.Scheduler{}.Run(t.Context(), []day.Task{{Puzzle: p, Part: 1}}, func(r day.Result) {
+		solved = r.Input
+	}, day.WithReader(strings.NewReader("1\n2\n")))
+
+	// the same input, saved on Windows with an empty line at the end
+	hash, err := InputHash(p, day.WithReader(strings.NewReader("1\r\n2\r\n\r\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if solved == "" || hash != solved {
+		t.Errorf("want the hash of the solved input %q, got %q", solved, hash)
+	}
+}
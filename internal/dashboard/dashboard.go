@@ -0,0 +1,313 @@
+// Package dashboard serves a local web page with a calendar of all
+// registered days: their recorded answers, the outcome of the last time
+// they were verified, their latest benchmark timings and a chart of their
+// benchmark history. Every day has a button that verifies it again.
+//
+// The page reads the same answers directory and bench history as the aoc
+// commands, on every request, so it shows what the commands recorded last
+// and works without a network connection. Verifications started from the
+// page are recorded next to the answers, like those of aoc verify, see
+// answers.SaveRun.
+//
+// Verifying only takes requests from the page itself: a POST from another
+// origin, as a page on another site would send, is refused.
+package dashboard
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"adventofcode/internal/answers"
+	"adventofcode/internal/bench"
+	"adventofcode/internal/day"
+)
+
+//go:embed dashboard.html
+var page string
+
+var tmpl = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"duration": duration,
+}).Parse(page))
+
+// Server serves the dashboard. Its fields are read on every request.
+type Server struct {
+	// AnswersDir is the answers directory, see answers.Dir.
+	AnswersDir string
+	// InputsDir is the inputs directory the days read their input from,
+	// or empty for the default.
+	InputsDir string
+	// BenchHistory is the bench history file.
+	BenchHistory string
+	// Timeout limits how long verifying a day may take, or 0 for no limit.
+	Timeout time.Duration
+
+	mux *http.ServeMux
+}
+
+// New returns a server that reads answers from answersDir, inputs from
+// inputsDir and timings from the bench history file at benchHistory.
+func New(answersDir, inputsDir, benchHistory string) *Server {
+	s := &Server{
+		AnswersDir:   answersDir,
+		InputsDir:    inputsDir,
+		BenchHistory: benchHistory,
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /{$}", s.calendar)
+	s.mux.HandleFunc("POST /verify/{year}/{day}", s.verify)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// calendarPage is what the template is executed with.
+type calendarPage struct {
+	Years  []calendarYear
+	Errors []string
+}
+
+type calendarYear struct {
+	Year int
+	Days []calendarDay
+}
+
+// calendarDay is a square of the calendar, with an entry per variant.
+type calendarDay struct {
+	Day     int
+	Entries []entry
+}
+
+type entry struct {
+	Name, Label, Anchor string
+	Year                int
+	Parts               []part
+	Verified            time.Time
+	Bench               []bench.Result
+	Chart               chart
+}
+
+type part struct {
+	Part      int
+	Want, Got string
+	Status    string
+	Elapsed   time.Duration
+}
+
+// chart is a line of the total benchmark time of a puzzle over the runs in
+// the history, drawn in a Width by Height box.
+type chart struct {
+	Points        string
+	Width, Height int
+	Runs          int
+	Last, Max     time.Duration
+}
+
+func (s *Server) calendar(w http.ResponseWriter, r *http.Request) {
+	var p calendarPage
+
+	history, err := bench.LoadHistory(s.BenchHistory)
+	if err != nil {
+		p.Errors = append(p.Errors, err.Error())
+	}
+
+	for _, year := range day.Years() {
+		y := calendarYear{Year: year}
+		for n := 1; n <= 25; n++ {
+			y.Days = append(y.Days, calendarDay{Day: n})
+		}
+		p.Years = append(p.Years, y)
+	}
+
+	for _, puzzle := range day.Puzzles() {
+		e, err := s.entry(puzzle, history)
+		if err != nil {
+			p.Errors = append(p.Errors, err.Error())
+		}
+
+		for i := range p.Years {
+			if y := &p.Years[i]; y.Year == puzzle.Year && puzzle.Day >= 1 && puzzle.Day <= len(y.Days) {
+				y.Days[puzzle.Day-1].Entries = append(y.Days[puzzle.Day-1].Entries, e)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(w, p); err != nil {
+		log.Printf("dashboard: %v", err)
+	}
+}
+
+// entry collects what is known about a puzzle. It returns the entry along
+// with the error of reading its answers or its last verification, if any.
+func (s *Server) entry(p day.Puzzle, history []bench.Record) (entry, error) {
+	e := entry{
+		Name:   p.Name(),
+		Label:  fmt.Sprintf("%02d%s", p.Day, p.Variant),
+		Anchor: anchor(p),
+		Year:   p.Year,
+	}
+
+	recorded, err := answers.Load(s.AnswersDir, p.Year, p.Day)
+	for n := 1; n <= 2; n++ {
+		e.Parts = append(e.Parts, part{Part: n, Want: display(recorded.Get(n))})
+	}
+
+	run, runErr := answers.LoadRun(s.AnswersDir, p)
+	e.Verified = run.Time
+
+	for _, r := range run.Parts {
+		if r.Part < 1 || r.Part > len(e.Parts) {
+			continue
+		}
+
+		pt := &e.Parts[r.Part-1]
+		pt.Got, pt.Status, pt.Elapsed = display(r.Got), strings.ToLower(r.Status.String()), r.Elapsed
+		if r.Err != "" {
+			pt.Got = r.Err
+		}
+	}
+
+	e.Bench, e.Chart = timings(p.Name(), history)
+
+	return e, errors.Join(err, runErr)
+}
+
+// display shortens hashed answers, which mean nothing to a reader.
+func display(want string) string {
+	if strings.HasPrefix(want, "sha256:") {
+		return "(hashed)"
+	}
+
+	return want
+}
+
+// anchor returns the id of a puzzle's entry on the page.
+func anchor(p day.Puzzle) string {
+	return fmt.Sprintf("d%d-%02d%s", p.Year, p.Day, p.Variant)
+}
+
+// timings returns the phases of the latest benchmark of a puzzle in
+// history, and a chart of its total time over all runs that timed it.
+func timings(name string, history []bench.Record) ([]bench.Result, chart) {
+	var latest []bench.Result
+	var totals []time.Duration
+
+	for _, record := range history {
+		var phases []bench.Result
+		var total time.Duration
+
+		for _, r := range record.Results {
+			if r.Day == name && r.Err == "" {
+				phases = append(phases, r)
+				total += r.Median
+			}
+		}
+
+		if len(phases) > 0 {
+			latest = phases
+			totals = append(totals, total)
+		}
+	}
+
+	return latest, newChart(totals, 160, 32)
+}
+
+func newChart(values []time.Duration, width, height int) chart {
+	c := chart{Width: width, Height: height, Runs: len(values)}
+	if len(values) == 0 {
+		return c
+	}
+
+	c.Last, c.Max = values[len(values)-1], slices.Max(values)
+
+	var points []string
+	for i, v := range values {
+		x := float64(width) / 2
+		if len(values) > 1 {
+			x = float64(i*width) / float64(len(values)-1)
+		}
+
+		y := float64(height)
+		if c.Max > 0 {
+			y -= float64(v) / float64(c.Max) * float64(height-2)
+		}
+
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+
+	c.Points = strings.Join(points, " ")
+	return c
+}
+
+// duration rounds d for display.
+func duration(d time.Duration) string {
+	switch {
+	case d == 0:
+		return ""
+	case d < time.Microsecond:
+		return d.String()
+	case d < time.Millisecond:
+		return d.Round(time.Microsecond / 10).String()
+	default:
+		return d.Round(time.Millisecond / 10).String()
+	}
+}
+
+// verify verifies a puzzle against its recorded answers, records the
+// outcome, and sends the browser back to the puzzle's entry.
+func (s *Server) verify(w http.ResponseWriter, r *http.Request) {
+	if !sameOrigin(r) {
+		http.Error(w, "cross-origin request refused", http.StatusForbidden)
+		return
+	}
+
+	p, ok := day.Lookup(r.PathValue("year") + "/" + r.PathValue("day"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	results, err := answers.Verify(ctx, s.AnswersDir, p, day.WithInputsDir(s.InputsDir))
+	if err == nil {
+		err = answers.SaveRun(s.AnswersDir, p, time.Now(), results)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/#"+anchor(p), http.StatusSeeOther)
+}
+
+// sameOrigin reports whether r comes from the dashboard itself, or from a
+// client that isn't a browser. Browsers send an Origin header with every
+// POST, and older ones at least Sec-Fetch-Site.
+func sameOrigin(r *http.Request) bool {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		return err == nil && u.Host == r.Host
+	}
+
+	site := r.Header.Get("Sec-Fetch-Site")
+	return site == "" || site == "same-origin" || site == "none"
+}
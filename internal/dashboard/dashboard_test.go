@@ -0,0 +1,196 @@
+package dashboard
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"adventofcode/internal/answers"
+	"adventofcode/internal/bench"
+	"adventofcode/internal/day"
+)
+
+type fake struct{}
+
+func (fake) Part1() (day.Answer, error) { return day.Int(42), nil }
+func (fake) Part2() (day.Answer, error) { return day.Answer{}, errors.New("boom") }
+
+func init() {
+	day.Register(2015, 3, "", func(...day.Option) (fake, error) { return fake{}, nil })
+	day.Register(2015, 3, "b", func(...day.Option) (fake, error) { return fake{}, nil })
+}
+
+func newServer(t *testing.T) *Server {
+	t.Helper()
+
+	dir := t.TempDir()
+	s := New(filepath.Join(dir, "answers"), dir, filepath.Join(dir, "bench.jsonl"))
+
+	var r answers.Record
+	r.Set(2015, 3, 1, day.Int(42), false)
+	r.Set(2015, 3, 2, day.Int(7), true)
+	if err := answers.Save(s.AnswersDir, 2015, 3, r); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, median := range []time.Duration{3 * time.Millisecond, 2 * time.Millisecond} {
+		record := bench.Record{Commit: string(rune('a' + i)), Results: []bench.Result{
+			{Day: "2015/03", Phase: "parse", Median: median / 2},
+			{Day: "2015/03", Phase: "part1", Median: median / 2},
+		}}
+		if err := bench.AppendHistory(s.BenchHistory, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s
+}
+
+func get(t *testing.T, h http.Handler) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /: want status 200, got %d: %s", w.Code, w.Body)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("want an HTML page, got %s", ct)
+	}
+
+	return w.Body.String()
+}
+
+func TestCalendar(t *testing.T) {
+	body := get(t, newServer(t))
+
+	for _, want := range []string{
+		"<h2>2015</h2>",
+		`id="d2015-03"`,
+		`id="d2015-03b"`,
+		`action="/verify/2015/03b"`,
+		"<code>42</code>",
+		"(hashed)",
+		"2 runs, last 2ms, slowest 3ms",
+		"<polyline points=",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("want the page to contain %s", want)
+		}
+	}
+
+	if strings.Contains(body, "sha256:") {
+		t.Error("want hashed answers hidden")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	s := newServer(t)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("POST", "/verify/2015/03", nil))
+
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("want status 303, got %d: %s", w.Code, w.Body)
+	}
+	if loc, _ := url.Parse(w.Header().Get("Location")); loc == nil || loc.Fragment != "d2015-03" {
+		t.Errorf("want a redirect to the day's entry, got %s", w.Header().Get("Location"))
+	}
+
+	body := get(t, s)
+
+	for _, want := range []string{`<span class="ok">ok</span> <code>42</code>`, `<span class="failed">failed</span> <code>boom</code>`, "verified at"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("want the page to contain %s", want)
+		}
+	}
+
+	// only the verified variant has an outcome
+	if n := strings.Count(body, "verified at"); n != 1 {
+		t.Errorf("want one verified entry, got %d", n)
+	}
+
+	// the outcome is kept on disk, for the next server
+	restarted := New(s.AnswersDir, s.InputsDir, s.BenchHistory)
+	if body := get(t, restarted); !strings.Contains(body, `<span class="ok">ok</span> <code>42</code>`) {
+		t.Error("want the outcome shown after a restart")
+	}
+}
+
+func TestVerifiedByCLI(t *testing.T) {
+	s := newServer(t)
+
+	// as aoc verify records it
+	p, _ := day.Lookup("2015/03b")
+	results, err := answers.Verify(t.Context(), s.AnswersDir, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := answers.SaveRun(s.AnswersDir, p, time.Now(), results); err != nil {
+		t.Fatal(err)
+	}
+
+	body := get(t, s)
+	if n := strings.Count(body, "verified at"); n != 1 {
+		t.Errorf("want one verified entry, got %d", n)
+	}
+	if !strings.Contains(body, `<span class="failed">failed</span> <code>boom</code>`) {
+		t.Error("want the outcome of aoc verify shown")
+	}
+}
+
+func TestCrossOrigin(t *testing.T) {
+	s := newServer(t)
+
+	tests := []struct {
+		header, value string
+		want          int
+	}{
+		{"Origin", "http://example.com", http.StatusSeeOther},
+		{"Origin", "http://evil.example", http.StatusForbidden},
+		{"Origin", "null", http.StatusForbidden},
+		{"Sec-Fetch-Site", "same-origin", http.StatusSeeOther},
+		{"Sec-Fetch-Site", "cross-site", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/verify/2015/03", nil)
+		r.Header.Set(tt.header, tt.value)
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+
+		if w.Code != tt.want {
+			t.Errorf("%s %s: want status %d, got %d", tt.header, tt.value, tt.want, w.Code)
+		}
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	s := newServer(t)
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{"POST", "/verify/2015/04", http.StatusNotFound},
+		{"POST", "/verify/2015/x", http.StatusNotFound},
+		{"GET", "/verify/2015/03", http.StatusMethodNotAllowed},
+		{"GET", "/nothing", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+		if w.Code != tt.want {
+			t.Errorf("%s %s: want status %d, got %d", tt.method, tt.path, tt.want, w.Code)
+		}
+	}
+}
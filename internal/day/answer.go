@@ -0,0 +1,60 @@
+package day
+
+import (
+	"math/big"
+	"strconv"
+)
+
+type Kind int
+
+const (
+	IntKind Kind = iota
+	BigIntKind
+	StringKind
+)
+
+// Answer is the solution to one part of a puzzle. Answers are comparable, so
+// the answers of a solver can be checked against expected ones with ==.
+type Answer struct {
+	kind  Kind
+	value string
+}
+
+func (k Kind) String() string {
+	switch k {
+	case IntKind:
+		return "int"
+	case BigIntKind:
+		return "bigint"
+	case StringKind:
+		return "string"
+	default:
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+func Int(n int) Answer {
+	return Answer{IntKind, strconv.Itoa(n)}
+}
+
+// BigInt returns an answer for n, which is an Int answer whenever n fits in
+// an int, so an answer compares equal regardless of how it was computed.
+func BigInt(n *big.Int) Answer {
+	if n.IsInt64() && int64(int(n.Int64())) == n.Int64() {
+		return Int(int(n.Int64()))
+	}
+
+	return Answer{BigIntKind, n.String()}
+}
+
+func String(s string) Answer {
+	return Answer{StringKind, s}
+}
+
+func (a Answer) Kind() Kind {
+	return a.kind
+}
+
+func (a Answer) String() string {
+	return a.value
+}
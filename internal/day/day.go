@@ -2,28 +2,125 @@ package day
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
+
+	"adventofcode/internal/secrets"
 )
 
 type Day interface {
-	Part1() int
-	Part2() int
+	Part1() (Answer, error)
+	Part2() (Answer, error)
+}
+
+// ContextDay is a day whose parts can be cancelled. Days with parts that
+// run for long, or forever on a bad input, implement it, and return the
+// context's error once it is done. Their Part1 and Part2 use a background
+// context.
+type ContextDay interface {
+	Day
+	Part1Context(ctx context.Context) (Answer, error)
+	Part2Context(ctx context.Context) (Answer, error)
 }
 
+// DayInput reads puzzle input from the file named by Input, from standard
+// input when Input is "-", from one of the examples embedded in the day's
+// package, or from a reader passed with WithReader.
+//
+// Without any of these, the input is read from a file per day in the inputs
+// directory: <inputs>/2024/day07.txt. The inputs directory is set with
+// -inputs or the AOC_INPUTS environment variable, and defaults to the
+// user's cache directory.
 type DayInput struct {
-	Input string
+	Input     string
+	InputsDir string
+	year      int
+	day       int
+	examples  fs.FS
+	fsys      fs.FS
+	buffer    *buffer
+	noCache   bool
+	params    map[string]int
+	declared  []Param
+	hash      *string
+	shape     Shape
+}
+
+// buffer holds input that can only be read once, like standard input, so
+// that every part of a day can read it.
+type buffer struct {
+	once sync.Once
+	r    io.Reader
+	data []byte
+	err  error
+}
+
+type Option func(*DayInput) error
+
+// stdin is shared by all days, as standard input can only be read once.
+var stdin = &buffer{r: os.Stdin}
+
+func (b *buffer) read() ([]byte, error) {
+	b.once.Do(func() {
+		b.data, b.err = io.ReadAll(b.r)
+	})
+
+	return b.data, b.err
 }
 
-type Option func(*DayInput)
+// Name returns the name of the input for messages: its file, stdin, or
+// reader.
+func (d DayInput) Name() string {
+	switch d.Input {
+	case "":
+		return "reader"
+	case "-":
+		return "stdin"
+	default:
+		return d.Input
+	}
+}
+
+// Open returns the input for reading. Callers must close it.
+func (d DayInput) Open() (io.ReadCloser, error) {
+	switch {
+	case d.buffer != nil:
+		data, err := d.buffer.read()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Name(), err)
+		}
+
+		return io.NopCloser(bytes.NewReader(data)), nil
+	case d.fsys != nil:
+		return d.fsys.Open(d.Input)
+	default:
+		// inputs may be kept encrypted, as Input.enc
+		path, err := secrets.Find(d.Input)
+		if err != nil || !secrets.Encrypted(path) {
+			return os.Open(d.Input)
+		}
+
+		data, err := secrets.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+}
 
-func (d DayInput) ReadLines() []string {
-	file, err := os.Open(d.Input)
+func (d DayInput) ReadLines() ([]string, error) {
+	file, err := d.Open()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	defer file.Close()
 
@@ -35,25 +132,31 @@ func (d DayInput) ReadLines() []string {
 	}
 
 	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("%s: %w", d.Name(), err)
 	}
 
-	return result
+	return result, nil
 }
 
-func (d DayInput) ReadInput() []byte {
-	input, err := os.ReadFile(d.Input)
+func (d DayInput) ReadInput() ([]byte, error) {
+	file, err := d.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result, err := io.ReadAll(file)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("%s: %w", d.Name(), err)
 	}
 
-	return input
+	return result, nil
 }
 
-func (d DayInput) ReadByteGrid() [][]byte {
-	file, err := os.Open(d.Input)
+func (d DayInput) ReadByteGrid() ([][]byte, error) {
+	file, err := d.Open()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	defer file.Close()
 
@@ -65,42 +168,193 @@ func (d DayInput) ReadByteGrid() [][]byte {
 	}
 
 	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("%s: %w", d.Name(), err)
 	}
 
-	return result
+	return result, nil
 }
 
-func NewDayInput(path string, opts ...Option) DayInput {
-	d := DayInput{
-		Input: filepath.Join(path, "input.txt"),
+// ReadGrid reads the lines of an input that is a grid, and fails unless
+// there is at least one row, and all rows have the same length.
+func (d DayInput) ReadGrid() ([]string, error) {
+	lines, err := d.ReadLines()
+	if err != nil {
+		return nil, err
 	}
+
+	if len(lines) == 0 || len(lines[0]) == 0 {
+		return nil, &ParseError{File: d.Name(), Line: 1, Err: errors.New("empty grid")}
+	}
+
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			err := fmt.Errorf("row of length %d in a grid %d wide", len(line), len(lines[0]))
+			return nil, &ParseError{File: d.Name(), Line: i + 1, Err: err}
+		}
+	}
+
+	return lines, nil
+}
+
+// NewDayInput returns the input for the given day of a year. Examples
+// holds the example inputs that are embedded in the day's package.
+func NewDayInput(year, day int, examples fs.FS, opts ...Option) (DayInput, error) {
+	d := DayInput{year: year, day: day, examples: examples}
 	for _, opt := range opts {
-		opt(&d)
+		if err := opt(&d); err != nil {
+			return d, err
+		}
+	}
+	if d.Input == "" && d.buffer == nil {
+		dir, err := InputsDir(d.InputsDir)
+		if err != nil {
+			return d, err
+		}
+		d.Input = InputPath(dir, year, day)
+	}
+	if d.Input == "-" && d.fsys == nil {
+		d.buffer = stdin
 	}
-	return d
+	if err := d.checkParams(); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// InputsDir returns dir, or when it is empty, the inputs directory from the
+// AOC_INPUTS environment variable or the user's cache directory.
+func InputsDir(dir string) (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+
+	if dir := os.Getenv("AOC_INPUTS"); dir != "" {
+		return dir, nil
+	}
+
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("no inputs directory, set -inputs or AOC_INPUTS: %w", err)
+	}
+
+	return filepath.Join(cache, "adventofcode"), nil
 }
 
+// InputPath returns where the input for a day is kept in the inputs
+// directory dir.
+func InputPath(dir string, year, day int) string {
+	return filepath.Join(dir, fmt.Sprint(year), fmt.Sprintf("day%02d.txt", day))
+}
+
+// FromArgs parses command line flags: the input flags, and a flag for each
+// parameter of the day. Asking for help is reported as flag.ErrHelp,
+// leaving it to the caller to decide how to exit. The flags are parsed
+// once, so the option can be used for more than one day of a puzzle.
 func FromArgs(args []string) Option {
-	return func(d *DayInput) {
-		fset := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
-		fset.StringVar(&d.Input, "i", d.Input, "input file")
-		if err := fset.Parse(args); err != nil {
-			if err == flag.ErrHelp {
-				os.Exit(0)
+	var (
+		once   sync.Once
+		option Option
+		err    error
+	)
+
+	return func(d *DayInput) error {
+		once.Do(func() {
+			fset := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+			inputFlags := InputFlags(fset)
+			paramFlags := ParamFlags(fset, d.declared)
+
+			if err = fset.Parse(args); err != nil {
+				return
+			}
+
+			input, params := inputFlags(), paramFlags()
+			option = func(d *DayInput) error {
+				if err := input(d); err != nil {
+					return err
+				}
+				return params(d)
+			}
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return option(d)
+	}
+}
+
+// InputFlags defines the flags that choose the input on fset, for commands
+// that have flags of their own. It returns a function that makes the
+// option from the flags once fset is parsed.
+func InputFlags(fset *flag.FlagSet) func() Option {
+	var input, example, inputsDir string
+
+	fset.StringVar(&input, "i", "", "input file, or - for standard input")
+	fset.StringVar(&example, "e", "", "embedded example input, e.g. example.txt")
+	fset.StringVar(&inputsDir, "inputs", "", "inputs directory (default $AOC_INPUTS or the user cache directory)")
+
+	return func() Option {
+		return func(d *DayInput) error {
+			if inputsDir != "" {
+				d.InputsDir = inputsDir
+			}
+
+			switch {
+			case input != "" && example != "":
+				return fmt.Errorf("flags -i and -e are mutually exclusive")
+			case input != "":
+				return WithInput(input)(d)
+			case example != "":
+				return WithExample(example)(d)
+			default:
+				return nil
 			}
-			log.Fatal(err)
 		}
 	}
 }
 
 func WithInput(input string) Option {
-	return func(d *DayInput) {
+	return func(d *DayInput) error {
 		d.Input = input
+		d.fsys = nil
+		d.buffer = nil
+		return nil
+	}
+}
+
+// WithInputsDir reads the input from the inputs directory dir.
+func WithInputsDir(dir string) Option {
+	return func(d *DayInput) error {
+		d.InputsDir = dir
+		return nil
 	}
 }
 
-func Solve(p Day) {
-	fmt.Println(p.Part1())
-	fmt.Println(p.Part2())
+// WithExample reads the input from an example embedded in the day's package.
+func WithExample(name string) Option {
+	return func(d *DayInput) error {
+		if d.examples == nil {
+			return fmt.Errorf("day %d has no embedded examples", d.day)
+		}
+
+		d.Input = name
+		d.fsys = d.examples
+		d.buffer = nil
+		return nil
+	}
+}
+
+// WithReader reads the input from r instead of a file. The input is read
+// once, and kept in memory for all parts, and for every day created with
+// the option.
+func WithReader(r io.Reader) Option {
+	b := &buffer{r: r}
+
+	return func(d *DayInput) error {
+		d.Input = ""
+		d.fsys = nil
+		d.buffer = b
+		return nil
+	}
 }
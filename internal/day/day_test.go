@@ -0,0 +1,66 @@
+package day
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"adventofcode/internal/secrets"
+)
+
+func TestReadGrid(t *testing.T) {
+	tests := []struct {
+		input string
+		line  int
+	}{
+		{"abc\ndef\n", 0},
+		{"", 1},
+		{"\n", 1},
+		{"abc\nde\nfgh\n", 2},
+	}
+
+	for _, tt := range tests {
+		d, err := NewDayInput(2024, 1, nil, WithReader(strings.NewReader(tt.input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lines, err := d.ReadGrid()
+
+		var parseErr *ParseError
+		switch {
+		case tt.line == 0 && err != nil:
+			t.Errorf("%q: %v", tt.input, err)
+		case tt.line == 0 && len(lines) != 2:
+			t.Errorf("%q: want 2 rows, got %q", tt.input, lines)
+		case tt.line != 0 && !errors.As(err, &parseErr):
+			t.Errorf("%q: want a parse error, got %v", tt.input, err)
+		case tt.line != 0 && parseErr.Line != tt.line:
+			t.Errorf("%q: want an error on line %d, got %v", tt.input, tt.line, err)
+		}
+	}
+}
+
+func TestEncryptedInput(t *testing.T) {
+	k, _ := secrets.NewKey()
+	t.Setenv("AOC_KEY", k.String())
+
+	name := filepath.Join(t.TempDir(), "input.txt")
+	if err := secrets.WriteFile(name+secrets.Ext, []byte("a\nb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewDayInput(2024, 1, nil, WithInput(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := d.ReadLines()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(lines, ",") != "a,b" {
+		t.Errorf("want a,b, got %q", lines)
+	}
+}
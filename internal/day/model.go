@@ -0,0 +1,182 @@
+package day
+
+import (
+	"container/list"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// modelKey identifies a parsed model: the parse function that made it, and
+// the input it was made from.
+type modelKey struct {
+	parse uintptr
+	model reflect.Type
+	input uint64
+}
+
+// modelEntry holds a model once it is parsed. Days created at the same
+// time for the same input wait for one parse rather than each parsing.
+type modelEntry struct {
+	key   modelKey
+	once  sync.Once
+	model any
+	err   error
+}
+
+// MaxModels is the number of models kept in the cache. A run of all days
+// needs one per day; the least recently used models are dropped first, so
+// that long running commands, like aoc serve, don't keep every input they
+// ever parsed.
+const MaxModels = 128
+
+// modelCache holds the most recently used models.
+type modelCache struct {
+	mu      sync.Mutex
+	entries map[modelKey]*list.Element
+	// recent holds the *modelEntry of every key, most recently used first
+	recent list.List
+}
+
+var models = modelCache{entries: make(map[modelKey]*list.Element)}
+
+// get returns the entry for key, adding an empty one when there is none,
+// and drops the least recently used entries beyond MaxModels.
+func (c *modelCache) get(key modelKey) *modelEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.recent.MoveToFront(elem)
+		return elem.Value.(*modelEntry)
+	}
+
+	e := &modelEntry{key: key}
+	c.entries[key] = c.recent.PushFront(e)
+
+	for c.recent.Len() > MaxModels {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*modelEntry).key)
+	}
+
+	return e
+}
+
+// remove drops e, unless it was dropped or replaced already.
+func (c *modelCache) remove(e *modelEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[e.key]; ok && elem.Value == e {
+		c.recent.Remove(elem)
+		delete(c.entries, e.key)
+	}
+}
+
+// len returns the number of cached models.
+func (c *modelCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.recent.Len()
+}
+
+// Load parses the input into a model with parse. A day calls it from its
+// constructor, so that both parts work on the same model, and parsing is
+// timed apart from solving. Line endings are normalised to \n first, one
+// empty line at the end is dropped, and the input is checked against the
+// shape it is expected to have, if any.
+//
+// Models are cached per parse function and input contents, so creating a
+// day again for the same input doesn't parse it again, up to MaxModels. As
+// a model may be shared, the parts of a day must not modify it; a part that
+// needs to change it works on a copy.
+func Load[M any](d DayInput, parse func(DayInput) (M, error)) (M, error) {
+	var zero M
+
+	data, err := d.ReadInput()
+	if err != nil {
+		return zero, err
+	}
+
+	data = normalize(data)
+	if err := d.shape.check(d.Name(), data); err != nil {
+		return zero, err
+	}
+
+	// parse from the data read, rather than reading the input again
+	d.fsys = nil
+	d.buffer = &buffer{data: data}
+	d.buffer.once.Do(func() {})
+
+	hash := xxhash.Sum64(data)
+	if d.hash != nil {
+		*d.hash = formatHash(hash)
+	}
+
+	if d.noCache {
+		return parse(d)
+	}
+
+	key := modelKey{
+		reflect.ValueOf(parse).Pointer(),
+		reflect.TypeFor[M](),
+		hash,
+	}
+
+	e := models.get(key)
+
+	e.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				e.err = fmt.Errorf("%w: %v", ErrPanic, r)
+			}
+		}()
+
+		e.model, e.err = parse(d)
+	})
+
+	if e.err != nil {
+		// errors are not cached, the next day gets to try again
+		models.remove(e)
+		return zero, e.err
+	}
+
+	return e.model.(M), nil
+}
+
+// InputHash returns the xxhash of the input, normalised like Load does, as
+// it is given in Result.Input.
+func (d DayInput) InputHash() (string, error) {
+	data, err := d.ReadInput()
+	if err != nil {
+		return "", err
+	}
+
+	return formatHash(xxhash.Sum64(normalize(data))), nil
+}
+
+func formatHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// WithoutCache makes Load parse the input even when it parsed the same
+// input before, for timing the parser.
+func WithoutCache() Option {
+	return func(d *DayInput) error {
+		d.noCache = true
+		return nil
+	}
+}
+
+// withHash makes Load store the xxhash of the input in hash, so that
+// results can tell which input they were solved for.
+func withHash(hash *string) Option {
+	return func(d *DayInput) error {
+		d.hash = hash
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package day
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	parses := 0
+	parse := func(d DayInput) ([]string, error) {
+		parses++
+		return d.ReadLines()
+	}
+
+	load := func(input string, opts ...Option) []string {
+		t.Helper()
+
+		d, err := NewDayInput(2024, 1, nil, append(opts, WithReader(strings.NewReader(input)))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := Load(d, parse)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return m
+	}
+
+	first := load("a\nb\n")
+	second := load("a\nb\n")
+	if parses != 1 {
+		t.Errorf("want the same input parsed once, got %d parses", parses)
+	}
+	if len(first) != 2 || len(second) != 2 || &first[0] != &second[0] {
+		t.Errorf("want the cached model, got %q and %q", first, second)
+	}
+
+	if got := load("c\n"); len(got) != 1 || got[0] != "c" {
+		t.Errorf("want [c] for another input, got %q", got)
+	}
+	if parses != 2 {
+		t.Errorf("want another input parsed, got %d parses", parses)
+	}
+
+	load("a\nb\n", WithoutCache())
+	if parses != 3 {
+		t.Errorf("want WithoutCache to parse again, got %d parses", parses)
+	}
+}
+
+func TestLoadEvicts(t *testing.T) {
+	parses := 0
+	parse := func(d DayInput) ([]string, error) {
+		parses++
+		return d.ReadLines()
+	}
+
+	load := func(input string) {
+		t.Helper()
+
+		d, err := NewDayInput(2024, 1, nil, WithReader(strings.NewReader(input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(d, parse); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := range MaxModels + 1 {
+		load(fmt.Sprintln(i))
+	}
+	if n := models.len(); n > MaxModels {
+		t.Errorf("want at most %d cached models, got %d", MaxModels, n)
+	}
+
+	// the most recent model is still cached, the first one was dropped
+	load(fmt.Sprintln(MaxModels))
+	if parses != MaxModels+1 {
+		t.Errorf("want the last input cached, got %d parses", parses)
+	}
+	load(fmt.Sprintln(0))
+	if parses != MaxModels+2 {
+		t.Errorf("want the first input parsed again, got %d parses", parses)
+	}
+}
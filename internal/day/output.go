@@ -0,0 +1,148 @@
+package day
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Format is a way of writing the results of a run.
+type Format string
+
+const (
+	// Plain writes the answers only, a line per part, as they come in.
+	Plain Format = "plain"
+	// Table writes a row per part with all of its result.
+	Table Format = "table"
+	// JSON writes an array with an object per part, for other tools.
+	JSON Format = "json"
+)
+
+// Formats are all formats, for usage messages.
+var Formats = []Format{Plain, Table, JSON}
+
+// ParseFormat returns the format called s.
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown format %q, want one of %v", s, Formats)
+}
+
+// resultJSON is how a result is written as JSON; a task holds a function,
+// which doesn't encode.
+type resultJSON struct {
+	Day     string        `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer,omitempty"`
+	Type    string        `json:"type,omitempty"`
+	Elapsed time.Duration `json:"elapsed_ns"`
+	Input   string        `json:"input,omitempty"`
+	Err     string        `json:"error,omitempty"`
+}
+
+func (r Result) MarshalJSON() ([]byte, error) {
+	j := resultJSON{
+		Day:     r.Puzzle.Name(),
+		Part:    r.Part,
+		Elapsed: r.Elapsed,
+		Input:   r.Input,
+	}
+
+	if r.Err != nil {
+		j.Err = r.Err.Error()
+	} else {
+		j.Answer, j.Type = r.Answer.String(), r.Answer.Kind().String()
+	}
+
+	return json.Marshal(j)
+}
+
+// ResultWriter writes results in a format. Plain output is written as the
+// results are added; a table or JSON is written on Flush.
+type ResultWriter struct {
+	w      io.Writer
+	format Format
+	// Headers writes the name of a day above its answers in plain output,
+	// for runs of more than one day.
+	Headers bool
+	// Elapsed writes the time a part took after its answer in plain
+	// output.
+	Elapsed bool
+
+	results []Result
+}
+
+func NewResultWriter(w io.Writer, format Format) *ResultWriter {
+	return &ResultWriter{w: w, format: format}
+}
+
+// Add writes or keeps a result. Plain output leaves out failed parts,
+// leaving it to the caller to report their errors.
+func (rw *ResultWriter) Add(r Result) error {
+	if rw.format != Plain {
+		rw.results = append(rw.results, r)
+		return nil
+	}
+
+	if rw.Headers && r.Part == 1 {
+		if _, err := fmt.Fprintf(rw.w, "day %s\n", r.Puzzle.Name()); err != nil {
+			return err
+		}
+	}
+
+	var err error
+
+	switch {
+	case r.Err != nil:
+	case rw.Elapsed:
+		_, err = fmt.Fprintf(rw.w, "%s\t%s\n", r.Answer, r.Elapsed.Round(time.Microsecond))
+	default:
+		_, err = fmt.Fprintln(rw.w, r.Answer)
+	}
+
+	return err
+}
+
+// Flush writes the results kept for a table or JSON.
+func (rw *ResultWriter) Flush() error {
+	switch rw.format {
+	case Table:
+		return writeTable(rw.w, rw.results)
+	case JSON:
+		enc := json.NewEncoder(rw.w)
+		enc.SetIndent("", "  ")
+
+		results := rw.results
+		if results == nil {
+			results = []Result{}
+		}
+
+		return enc.Encode(results)
+	default:
+		return nil
+	}
+}
+
+func writeTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "DAY\tPART\tANSWER\tTYPE\tTIME\tINPUT\tERROR")
+
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(tw, "%s\t%d\t\t\t\t%s\t%s\n", r.Puzzle.Name(), r.Part, r.Input, r.Err)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t\n",
+			r.Puzzle.Name(), r.Part, r.Answer, r.Answer.Kind(), r.Elapsed.Round(time.Microsecond), r.Input)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,114 @@
+package day
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func outputResults() []Result {
+	p := Puzzle{Year: 2024, Day: 7, Variant: "b"}
+
+	return []Result{
+		{Task: Task{p, 1}, Answer: Int(3749), Elapsed: 1500 * time.Nanosecond, Input: "00000000000000ff"},
+		{Task: Task{p, 2}, Err: errors.New("boom"), Input: "00000000000000ff"},
+	}
+}
+
+func TestResultWriterPlain(t *testing.T) {
+	var b bytes.Buffer
+
+	w := NewResultWriter(&b, Plain)
+	w.Headers = true
+	for _, r := range outputResults() {
+		if err := w.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the error of part 2 is left to the caller
+	want := "day 2024/07b\n3749\n"
+	if b.String() != want {
+		t.Errorf("want %q, got %q", want, b.String())
+	}
+}
+
+func TestResultWriterJSON(t *testing.T) {
+	var b bytes.Buffer
+
+	w := NewResultWriter(&b, JSON)
+	for _, r := range outputResults() {
+		if err := w.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Len() != 0 {
+		t.Errorf("want JSON written on Flush, got %q", b.String())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]any{
+		{"day": "2024/07b", "part": 1.0, "answer": "3749", "type": "int", "elapsed_ns": 1500.0, "input": "00000000000000ff"},
+		{"day": "2024/07b", "part": 2.0, "elapsed_ns": 0.0, "input": "00000000000000ff", "error": "boom"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("want %d results, got %d", len(want), len(got))
+	}
+	for i := range want {
+		for k, v := range want[i] {
+			if got[i][k] != v {
+				t.Errorf("result %d: want %s %v, got %v", i, k, v, got[i][k])
+			}
+		}
+		if len(got[i]) != len(want[i]) {
+			t.Errorf("result %d: want fields %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestResultWriterTable(t *testing.T) {
+	var b bytes.Buffer
+
+	w := NewResultWriter(&b, Table)
+	for _, r := range outputResults() {
+		if err := w.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `DAY       PART  ANSWER  TYPE  TIME  INPUT             ERROR
+2024/07b  1     3749    int   2µs   00000000000000ff  
+2024/07b  2                         00000000000000ff  boom
+`
+	if b.String() != want {
+		t.Errorf("want\n%s\ngot\n%s", want, b.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		if got, err := ParseFormat(string(f)); got != f || err != nil {
+			t.Errorf("%s: got %q, %v", f, got, err)
+		}
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("want an error for xml")
+	}
+}
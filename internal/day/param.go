@@ -0,0 +1,131 @@
+package day
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Param is a named number that a puzzle depends on, like the size of its
+// grid, or the number of rounds to play. Days declare their parameters as
+// package variables, pass them to Register, and read them from their input
+// with DayInput.Param. The defaults are the values for the real input;
+// examples and variants of a puzzle set other values with WithParam or on
+// the command line.
+type Param struct {
+	Name    string
+	Default int
+	Usage   string
+	// Min and Max bound the values of the parameter, so that a bad value
+	// fails with an error rather than somewhere in the parts.
+	Min, Max int
+}
+
+// NewParam declares a parameter, which takes values from 0 up.
+func NewParam(name string, value int, usage string) Param {
+	return Param{name, value, usage, 0, math.MaxInt}
+}
+
+// Between returns p with values bounded by min and max, inclusive.
+func (p Param) Between(min, max int) Param {
+	p.Min, p.Max = min, max
+	return p
+}
+
+// Check returns an error when v is out of the bounds of p.
+func (p Param) Check(v int) error {
+	switch {
+	case v < p.Min:
+		return fmt.Errorf("parameter %s: want at least %d, got %d", p.Name, p.Min, v)
+	case v > p.Max:
+		return fmt.Errorf("parameter %s: want at most %d, got %d", p.Name, p.Max, v)
+	}
+
+	return nil
+}
+
+// Param returns the value of p for this input: the value set with
+// WithParam, or the default.
+func (d DayInput) Param(p Param) int {
+	if v, ok := d.params[p.Name]; ok {
+		return v
+	}
+
+	return p.Default
+}
+
+// WithParam sets the value of the parameter with the given name.
+func WithParam(name string, value int) Option {
+	return func(d *DayInput) error {
+		if d.params == nil {
+			d.params = make(map[string]int)
+		}
+
+		d.params[name] = value
+		return nil
+	}
+}
+
+// declare makes the parameters of a registered puzzle known to its input,
+// so that FromArgs can make flags for them, and so that values for other
+// parameters are rejected.
+func declare(params []Param) Option {
+	return func(d *DayInput) error {
+		// not nil, also for puzzles without parameters
+		d.declared = append([]Param{}, params...)
+		return nil
+	}
+}
+
+// checkParams fails on values for parameters that the day doesn't have,
+// and on values out of the bounds of their parameter. Days created without
+// Register declare nothing, and aren't checked.
+func (d DayInput) checkParams() error {
+	if d.declared == nil {
+		return nil
+	}
+
+	for name, v := range d.params {
+		i := slices.IndexFunc(d.declared, func(p Param) bool { return p.Name == name })
+		if i == -1 {
+			return fmt.Errorf("day %d has no parameter %q", d.day, name)
+		}
+
+		if err := d.declared[i].Check(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParamFlags defines a flag on fset for each of params, like InputFlags
+// does for the input. The option it returns sets the parameters that were
+// given on the command line.
+func ParamFlags(fset *flag.FlagSet, params []Param) func() Option {
+	values := make(map[string]*int, len(params))
+
+	for _, p := range params {
+		values[p.Name] = fset.Int(p.Name, p.Default, p.Usage)
+	}
+
+	return func() Option {
+		var opts []Option
+
+		fset.Visit(func(f *flag.Flag) {
+			if v, ok := values[f.Name]; ok {
+				opts = append(opts, WithParam(f.Name, *v))
+			}
+		})
+
+		return func(d *DayInput) error {
+			for _, opt := range opts {
+				if err := opt(d); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package day
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	size := NewParam("size", 71, "size of the grid").Between(1, 100)
+	rounds := NewParam("rounds", 10, "number of rounds")
+	declared := declare([]Param{size, rounds})
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		size, rounds int
+		err          string
+	}{
+		{"defaults", []Option{declared}, 71, 10, ""},
+		{"option", []Option{declared, WithParam("size", 7)}, 7, 10, ""},
+		{"flags", []Option{declared, FromArgs([]string{"-rounds", "3", "-size", "5"})}, 5, 3, ""},
+		{"flag and option", []Option{declared, FromArgs([]string{"-size", "5"}), WithParam("rounds", 1)}, 5, 1, ""},
+		{"undeclared", []Option{WithParam("size", 7)}, 7, 10, ""},
+		{"unknown", []Option{declared, WithParam("width", 7)}, 0, 0, `no parameter "width"`},
+		{"too small", []Option{declared, WithParam("size", 0)}, 0, 0, "parameter size: want at least 1, got 0"},
+		{"too large", []Option{declared, FromArgs([]string{"-size", "101"})}, 0, 0, "parameter size: want at most 100, got 101"},
+		{"negative", []Option{declared, WithParam("rounds", -1)}, 0, 0, "parameter rounds: want at least 0, got -1"},
+		{"unknown flag", []Option{declared, FromArgs([]string{"-width", "7"})}, 0, 0, "flag provided but not defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDayInput(2024, 1, nil, append(tt.opts, WithReader(strings.NewReader("")))...)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("want error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := d.Param(size); got != tt.size {
+				t.Errorf("want size %d, got %d", tt.size, got)
+			}
+			if got := d.Param(rounds); got != tt.rounds {
+				t.Errorf("want rounds %d, got %d", tt.rounds, got)
+			}
+		})
+	}
+}
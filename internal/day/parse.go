@@ -0,0 +1,77 @@
+package day
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError reports malformed puzzle input. Line and Column are 1-based,
+// and zero when unknown.
+type ParseError struct {
+	File         string
+	Line, Column int
+	Err          error
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Column > 0:
+		return fmt.Sprintf("%s line %d column %d: %v", e.File, e.Line, e.Column, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("%s line %d: %v", e.File, e.Line, e.Err)
+	default:
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseLines reads the input and calls parse for every line. A panic in
+// parse, like the one conv.MustAtoi raises for a malformed number, is
+// returned as a *ParseError pointing at the offending line.
+func ParseLines[T any](d DayInput, parse func(line string) T) ([]T, error) {
+	lines, err := d.ReadLines()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, len(lines))
+
+	for i, line := range lines {
+		if err := catch(func() { result[i] = parse(line) }); err != nil {
+			return nil, &ParseError{d.Name(), i + 1, column(line, err), err}
+		}
+	}
+
+	return result, nil
+}
+
+func catch(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	fn()
+
+	return nil
+}
+
+// column locates the text a strconv function choked on.
+func column(line string, err error) int {
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) || numErr.Num == "" {
+		return 0
+	}
+
+	return strings.Index(line, numErr.Num) + 1
+}
@@ -0,0 +1,131 @@
+package day
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Puzzle is a registered solution for one day of the calendar of a year.
+// Alternate solutions for the same day are told apart by their variant,
+// e.g. "b", and must give the same answers, see Variants.
+type Puzzle struct {
+	Year    int
+	Day     int
+	Variant string
+	New     func(opts ...Option) (Day, error)
+	Params  []Param
+}
+
+var registry = make(map[string]Puzzle)
+
+// Name returns the name of the puzzle, e.g. 2024/07b.
+func (p Puzzle) Name() string {
+	return fmt.Sprintf("%d/%02d%s", p.Year, p.Day, p.Variant)
+}
+
+// Register makes a solution available to the runner. It is meant to be
+// called from the init function of a day's package, and panics when the
+// same year, day and variant are registered twice. Params are the
+// parameters that the constructor reads from its input.
+func Register[D Day](year, day int, variant string, constructor func(opts ...Option) (D, error), params ...Param) {
+	p := Puzzle{year, day, variant, func(opts ...Option) (Day, error) {
+		d, err := constructor(append([]Option{declare(params)}, opts...)...)
+		return d, err
+	}, params}
+
+	if _, ok := registry[p.Name()]; ok {
+		panic("day: Register called twice for day " + p.Name())
+	}
+
+	registry[p.Name()] = p
+}
+
+// Part creates the puzzle's day from opts and returns the answer to part n,
+// 1 or 2, or the error of ctx once it is done. A panic while solving is
+// returned as an error, so that one broken day doesn't take down a run of
+// all days.
+func (p Puzzle) Part(ctx context.Context, n int, opts ...Option) (answer Answer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+
+	d, err := p.New(opts...)
+	if err != nil {
+		return Answer{}, err
+	}
+
+	return SolvePart(ctx, d, n)
+}
+
+// Lookup finds a registered puzzle by name, accepting "2024/07b" as well
+// as "2024/7b". Without a year, like "7b", it finds the puzzle of the
+// latest year that has it.
+func Lookup(name string) (Puzzle, bool) {
+	yearText, name, ok := strings.Cut(name, "/")
+	if !ok {
+		yearText, name = "", yearText
+	}
+
+	i := strings.IndexFunc(name, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if i == -1 {
+		i = len(name)
+	}
+
+	n, err := strconv.Atoi(name[:i])
+	if err != nil {
+		return Puzzle{}, false
+	}
+
+	years := Years()
+	if yearText != "" {
+		year, err := strconv.Atoi(yearText)
+		if err != nil {
+			return Puzzle{}, false
+		}
+		years = []int{year}
+	}
+
+	for _, year := range slices.Backward(years) {
+		if p, ok := registry[Puzzle{Year: year, Day: n, Variant: name[i:]}.Name()]; ok {
+			return p, true
+		}
+	}
+
+	return Puzzle{}, false
+}
+
+// Years returns the years that have registered puzzles, in order.
+func Years() []int {
+	var years []int
+
+	for _, p := range registry {
+		if !slices.Contains(years, p.Year) {
+			years = append(years, p.Year)
+		}
+	}
+
+	slices.Sort(years)
+	return years
+}
+
+// Puzzles returns all registered puzzles, ordered by year, day and variant.
+func Puzzles() []Puzzle {
+	result := make([]Puzzle, 0, len(registry))
+
+	for _, p := range registry {
+		result = append(result, p)
+	}
+
+	slices.SortFunc(result, func(a, b Puzzle) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return result
+}
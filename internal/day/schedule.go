@@ -0,0 +1,229 @@
+package day
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSharedState is returned by a scheduler that checks isolation,
+	// when a part changed the state of its day.
+	ErrSharedState = errors.New("part changed the state of its day")
+	// ErrTimeout is returned for parts that ran longer than the timeout of
+	// the scheduler.
+	ErrTimeout = errors.New("timed out")
+	// ErrPanic is returned, with the value of the panic, for parts that
+	// panicked.
+	ErrPanic = errors.New("panic")
+)
+
+// Task is a part of a puzzle to solve.
+type Task struct {
+	Puzzle Puzzle
+	Part   int
+}
+
+// Result holds the answer to a task, and how long solving it took. Elapsed
+// doesn't include creating the day; parsing is shared between the parts.
+// Input is the xxhash of the input the task was solved for.
+type Result struct {
+	Task
+	Answer  Answer
+	Err     error
+	Elapsed time.Duration
+	Input   string
+}
+
+// Scheduler solves tasks on a bounded number of workers.
+//
+// Every task creates its own day, so state a day builds on top of its model
+// is never shared between parts running at the same time. The model itself
+// is shared (see Load), which is only safe as long as parts don't change
+// it. Check finds parts that do, by comparing their day with a freshly
+// parsed one after solving.
+type Scheduler struct {
+	// Workers is the maximum number of tasks solved at the same time,
+	// runtime.GOMAXPROCS when it is zero. A part of a day that is no
+	// ContextDay is left running when it times out, and its worker goes on
+	// to the next task, so after timeouts more than Workers parts may be
+	// using the CPU; see Running.
+	Workers int
+	Check   bool
+	// Timeout is how long a part may take, without a limit when it is
+	// zero.
+	Timeout time.Duration
+	// Running, when set, counts the parts being solved, including the parts
+	// of days that are no ContextDay left running after they timed out, so
+	// that callers can wait until they really return.
+	Running *sync.WaitGroup
+}
+
+// Tasks returns both parts of every puzzle, in order.
+func Tasks(puzzles []Puzzle) []Task {
+	result := make([]Task, 0, 2*len(puzzles))
+
+	for _, p := range puzzles {
+		result = append(result, Task{p, 1}, Task{p, 2})
+	}
+
+	return result
+}
+
+// Run solves tasks, creating their days with opts, and calls report with
+// each result in the order of the tasks, whatever order they finish in.
+// Report is called from the goroutine calling Run. Once ctx is done, the
+// tasks that are left fail with its error.
+func (s Scheduler) Run(ctx context.Context, tasks []Task, report func(Result), opts ...Option) {
+	workers := s.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// tasks add options of their own, which must not share opts' array
+	opts = slices.Clip(opts)
+
+	results := make([]Result, len(tasks))
+	done := make([]chan struct{}, len(tasks))
+	slots := make(chan struct{}, workers)
+
+	for i, t := range tasks {
+		done[i] = make(chan struct{})
+
+		go func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = s.solve(ctx, t, opts)
+			close(done[i])
+		}()
+	}
+
+	for i := range tasks {
+		<-done[i]
+		report(results[i])
+	}
+}
+
+func (s Scheduler) solve(ctx context.Context, t Task, opts []Option) (result Result) {
+	result.Task = t
+
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+
+	d, err := t.Puzzle.New(append(opts, withHash(&result.Input))...)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, s.Timeout, fmt.Errorf("%w after %s", ErrTimeout, s.Timeout))
+		defer cancel()
+	}
+
+	start := time.Now()
+	result.Answer, result.Err = solvePart(ctx, d, t.Part, s.Running)
+	result.Elapsed = time.Since(start)
+
+	if result.Err != nil && ctx.Err() != nil {
+		result.Err = context.Cause(ctx)
+	}
+
+	if s.Check && result.Err == nil {
+		fresh, err := t.Puzzle.New(append(opts, WithoutCache())...)
+		if err != nil {
+			result.Err = err
+		} else if !reflect.DeepEqual(d, fresh) {
+			result.Err = ErrSharedState
+		}
+	}
+
+	return result
+}
+
+// SolvePart returns the answer to part n of d, 1 or 2. It returns the
+// error of ctx once it is done, also for days that are no ContextDay: their
+// part is left running in the background, and its answer is dropped.
+func SolvePart(ctx context.Context, d Day, n int) (Answer, error) {
+	return solvePart(ctx, d, n, nil)
+}
+
+// solvePart is SolvePart, counting the part in running until it returns
+// when running is set.
+func solvePart(ctx context.Context, d Day, n int, running *sync.WaitGroup) (Answer, error) {
+	if running != nil {
+		running.Add(1)
+		defer running.Done()
+	}
+
+	if n != 1 && n != 2 {
+		return Answer{}, fmt.Errorf("no part %d", n)
+	}
+
+	if d, ok := d.(ContextDay); ok {
+		if n == 1 {
+			return d.Part1Context(ctx)
+		}
+		return d.Part2Context(ctx)
+	}
+
+	part := d.Part1
+	if n == 2 {
+		part = d.Part2
+	}
+
+	if ctx.Done() == nil {
+		return part()
+	}
+
+	type result struct {
+		answer Answer
+		err    error
+	}
+
+	done := make(chan result, 1)
+
+	if running != nil {
+		running.Add(1)
+	}
+
+	go func() {
+		var r result
+
+		defer func() {
+			if running != nil {
+				running.Done()
+			}
+		}()
+		defer func() {
+			if p := recover(); p != nil {
+				r.err = fmt.Errorf("%w: %v", ErrPanic, p)
+			}
+			done <- r
+		}()
+
+		r.answer, r.err = part()
+	}()
+
+	select {
+	case r := <-done:
+		return r.answer, r.err
+	case <-ctx.Done():
+		return Answer{}, ctx.Err()
+	}
+}
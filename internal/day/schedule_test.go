@@ -0,0 +1,240 @@
+package day
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sleeper answers part n after sleeping for the duration of that part, and
+// keeps track of how many parts run at the same time.
+type sleeper struct {
+	sleeps  [2]time.Duration
+	running *counter
+}
+
+type counter struct {
+	mu       sync.Mutex
+	now, max int
+}
+
+func (c *counter) add(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now += n
+	c.max = max(c.max, c.now)
+}
+
+func (s sleeper) part(n int) (Answer, error) {
+	s.running.add(1)
+	defer s.running.add(-1)
+
+	time.Sleep(s.sleeps[n-1])
+	return Int(n), nil
+}
+
+func (s sleeper) Part1() (Answer, error) { return s.part(1) }
+func (s sleeper) Part2() (Answer, error) { return s.part(2) }
+
+func TestSchedulerOrder(t *testing.T) {
+	running := &counter{}
+	puzzles := []Puzzle{}
+
+	// the later days finish first
+	for n := 1; n <= 4; n++ {
+		sleep := time.Duration(5-n) * 5 * time.Millisecond
+		puzzles = append(puzzles, Puzzle{Year: 2024, Day: n, New: func(opts ...Option) (Day, error) {
+			return sleeper{[2]time.Duration{sleep, sleep / 2}, running}, nil
+		}})
+	}
+
+	var got []string
+	Scheduler{Workers: 3}.Run(t.Context(), Tasks(puzzles), func(r Result) {
+		if r.Err != nil {
+			t.Errorf("day %s part %d: %v", r.Puzzle.Name(), r.Part, r.Err)
+		}
+		if r.Elapsed <= 0 {
+			t.Errorf("day %s part %d: want the part timed", r.Puzzle.Name(), r.Part)
+		}
+		got = append(got, r.Puzzle.Name()+":"+r.Answer.String())
+	})
+
+	want := "2024/01:1 2024/01:2 2024/02:1 2024/02:2 2024/03:1 2024/03:2 2024/04:1 2024/04:2"
+	if strings.Join(got, " ") != want {
+		t.Errorf("want results in task order %s, got %s", want, got)
+	}
+
+	if running.max > 3 {
+		t.Errorf("want at most 3 parts at the same time, got %d", running.max)
+	}
+}
+
+// counts is a day that, when broken, counts its calls in its model.
+type counts struct {
+	calls  map[string]int
+	broken bool
+}
+
+func parseCounts(d DayInput) (map[string]int, error) {
+	lines, err := d.ReadLines()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]int)
+	for _, l := range lines {
+		result[l] = 0
+	}
+
+	return result, nil
+}
+
+func (c counts) Part1() (Answer, error) {
+	if c.broken {
+		c.calls["part1"]++
+	}
+	return Int(len(c.calls)), nil
+}
+
+func (c counts) Part2() (Answer, error) {
+	if c.broken {
+		panic("part 2 is broken")
+	}
+	return Int(len(c.calls)), nil
+}
+
+func TestSchedulerCheck(t *testing.T) {
+	for _, broken := range []bool{false, true} {
+		p := Puzzle{Year: 2024, Day: 1, New: func(opts ...Option) (Day, error) {
+			input, err := NewDayInput(2024, 1, nil, opts...)
+			if err != nil {
+				return nil, err
+			}
+
+			calls, err := Load(input, parseCounts)
+			return counts{calls, broken}, err
+		}}
+
+		var results []Result
+		Scheduler{Check: true}.Run(t.Context(), Tasks([]Puzzle{p}), func(r Result) {
+			results = append(results, r)
+		}, WithReader(strings.NewReader("part1\npart2\n")))
+
+		if len(results) != 2 {
+			t.Fatalf("want 2 results, got %d", len(results))
+		}
+
+		if !broken {
+			for _, r := range results {
+				if r.Err != nil || r.Answer != Int(2) {
+					t.Errorf("part %d: want 2, got %v, %v", r.Part, r.Answer, r.Err)
+				}
+			}
+			continue
+		}
+
+		if !errors.Is(results[0].Err, ErrSharedState) {
+			t.Errorf("want part 1 to change shared state, got %v", results[0].Err)
+		}
+		if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "part 2 is broken") {
+			t.Errorf("want part 2 to panic, got %v", results[1].Err)
+		}
+	}
+}
+
+// hung is a day that doesn't know about contexts, and hangs in part 1.
+type hung struct{}
+
+func (hung) Part1() (Answer, error) { select {} }
+func (hung) Part2() (Answer, error) { return Int(2), nil }
+
+// waiter is a ContextDay with a part 2 that waits until it is cancelled.
+type waiter struct{}
+
+func (w waiter) Part1() (Answer, error) { return w.Part1Context(context.Background()) }
+func (w waiter) Part2() (Answer, error) { return w.Part2Context(context.Background()) }
+
+func (waiter) Part1Context(ctx context.Context) (Answer, error) {
+	return Int(1), nil
+}
+
+func (waiter) Part2Context(ctx context.Context) (Answer, error) {
+	<-ctx.Done()
+	return Answer{}, ctx.Err()
+}
+
+func TestSchedulerTimeout(t *testing.T) {
+	puzzles := []Puzzle{
+		{Day: 1, New: func(opts ...Option) (Day, error) { return hung{}, nil }},
+		{Day: 2, New: func(opts ...Option) (Day, error) { return waiter{}, nil }},
+	}
+
+	var got []string
+	Scheduler{Workers: 1, Timeout: 10 * time.Millisecond}.Run(t.Context(), Tasks(puzzles), func(r Result) {
+		switch {
+		case errors.Is(r.Err, ErrTimeout):
+			got = append(got, "timeout")
+		case r.Err != nil:
+			t.Errorf("day %s part %d: %v", r.Puzzle.Name(), r.Part, r.Err)
+		default:
+			got = append(got, r.Answer.String())
+		}
+	})
+
+	// hung parts are left behind, and don't hold up the parts after them
+	want := "timeout 2 1 timeout"
+	if strings.Join(got, " ") != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	p := Puzzle{Year: 2024, Day: 1, New: func(opts ...Option) (Day, error) { return hung{}, nil }}
+
+	Scheduler{}.Run(ctx, Tasks([]Puzzle{p}), func(r Result) {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("part %d: want canceled, got %v", r.Part, r.Err)
+		}
+	})
+}
+
+// blocked is a day that doesn't know about contexts, and blocks in both
+// parts until its channel is closed.
+type blocked chan struct{}
+
+func (b blocked) Part1() (Answer, error) { <-b; return Int(1), nil }
+func (b blocked) Part2() (Answer, error) { <-b; return Int(2), nil }
+
+func TestSchedulerRunning(t *testing.T) {
+	release := make(blocked)
+	p := Puzzle{Year: 2024, Day: 1, New: func(opts ...Option) (Day, error) { return release, nil }}
+
+	var running sync.WaitGroup
+	Scheduler{Timeout: 10 * time.Millisecond, Running: &running}.Run(t.Context(), Tasks([]Puzzle{p}), func(r Result) {
+		if !errors.Is(r.Err, ErrTimeout) {
+			t.Errorf("part %d: want a timeout, got %v", r.Part, r.Err)
+		}
+	})
+
+	returned := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(returned)
+	}()
+
+	select {
+	case <-returned:
+		t.Fatal("want the timed out parts to be running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-returned
+}
@@ -0,0 +1,166 @@
+package day
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Shape is the layout of a puzzle input: one or more sections of lines,
+// separated by blank lines. Days declare the shape of their input as a
+// package variable, and load it with DayInput.Expect, so that malformed
+// input is reported with its position before it is parsed, rather than
+// as a panic somewhere in the parser.
+type Shape []Section
+
+// Section is a run of lines without blank lines between them.
+type Section struct {
+	// Name says what the lines are, for messages, e.g. "rules".
+	Name string
+	// Pattern is a regular expression that every line matches in full.
+	Pattern string
+	// Grid is a character class, like [.#], that every cell of a grid
+	// matches. All rows of a grid have the same length.
+	Grid string
+	// Lines is the number of lines, or 0 for any number.
+	Lines int
+	// Repeat allows any number of sections like the last one of a shape.
+	Repeat bool
+}
+
+// Expect returns the input, to be checked against shape when it is loaded.
+func (d DayInput) Expect(shape Shape) DayInput {
+	d.shape = shape
+	return d
+}
+
+// normalize makes line endings \n, so that inputs saved on Windows parse,
+// and drops one empty line at the end, as editors may leave.
+func normalize(data []byte) []byte {
+	if bytes.Contains(data, []byte{'\r'}) {
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	}
+
+	if bytes.HasSuffix(data, []byte("\n\n")) {
+		data = data[:len(data)-1]
+	}
+
+	return data
+}
+
+// patterns holds the compiled regular expressions of all shapes, by
+// expression, so that they are compiled once rather than for every input.
+var patterns sync.Map
+
+// compile returns the compiled form of expr, which must be valid.
+func compile(expr string) *regexp.Regexp {
+	if re, ok := patterns.Load(expr); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	re, _ := patterns.LoadOrStore(expr, regexp.MustCompile(expr))
+	return re.(*regexp.Regexp)
+}
+
+// check reports the first place where data doesn't have the shape.
+func (s Shape) check(file string, data []byte) error {
+	if len(s) == 0 {
+		return nil
+	}
+
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return &ParseError{File: file, Err: fmt.Errorf("empty input, want the %s", s[0].Name)}
+	}
+
+	lines := strings.Split(text, "\n")
+	last := s[len(s)-1]
+
+	for i, start := 0, 0; ; i++ {
+		section := last
+		if i < len(s) {
+			section = s[i]
+		}
+
+		end := start
+		for end < len(lines) && lines[end] != "" {
+			end++
+		}
+
+		if err := section.check(lines[start:end]); err != nil {
+			err.File, err.Line = file, err.Line+start
+			return err
+		}
+
+		if end == len(lines) {
+			if i+1 < len(s) {
+				err := fmt.Errorf("missing the %s after a blank line", s[i+1].Name)
+				return &ParseError{File: file, Line: end, Err: err}
+			}
+			return nil
+		}
+
+		if i+1 >= len(s) && !last.Repeat {
+			err := fmt.Errorf("unexpected blank line after the %s", section.Name)
+			return &ParseError{File: file, Line: end + 1, Err: err}
+		}
+
+		start = end + 1
+	}
+}
+
+// check checks the lines of a section. Line numbers in the error it returns
+// count from the first line of the section.
+func (s Section) check(lines []string) *ParseError {
+	if len(lines) == 0 {
+		return &ParseError{Line: 1, Err: fmt.Errorf("unexpected blank line, want the %s", s.Name)}
+	}
+
+	if s.Lines > 0 && len(lines) != s.Lines {
+		err := fmt.Errorf("the %s has %d lines, want %d", s.Name, len(lines), s.Lines)
+		return &ParseError{Line: min(len(lines), s.Lines+1), Err: err}
+	}
+
+	var pattern, grid, cell *regexp.Regexp
+
+	if s.Pattern != "" {
+		pattern = compile(`^(?:` + s.Pattern + `)$`)
+	}
+	if s.Grid != "" {
+		grid = compile(`^(?:` + s.Grid + `)*$`)
+		cell = compile(`^(?:` + s.Grid + `)$`)
+	}
+
+	for i, line := range lines {
+		if grid != nil && len(line) != len(lines[0]) {
+			err := fmt.Errorf("row of length %d in a grid %d wide", len(line), len(lines[0]))
+			return &ParseError{Line: i + 1, Err: err}
+		}
+
+		if (pattern == nil || pattern.MatchString(line)) && (grid == nil || grid.MatchString(line)) {
+			continue
+		}
+
+		if trimmed := strings.TrimRightFunc(line, unicode.IsSpace); len(trimmed) < len(line) {
+			return &ParseError{Line: i + 1, Column: len(trimmed) + 1, Err: errors.New("trailing space")}
+		}
+
+		if grid != nil && !grid.MatchString(line) {
+			for j, c := range line {
+				if !cell.MatchString(string(c)) {
+					err := fmt.Errorf("unexpected %q in the %s, want %s", c, s.Name, s.Grid)
+					return &ParseError{Line: i + 1, Column: j + 1, Err: err}
+				}
+			}
+		}
+
+		err := fmt.Errorf("bad line %q in the %s, want %s", line, s.Name, s.Pattern)
+		return &ParseError{Line: i + 1, Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package day
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testShape = Shape{
+	{Name: "rules", Pattern: `\d+\|\d+`},
+	{Name: "map", Grid: `[.#]`, Lines: 2},
+}
+
+func TestShape(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		line, column int
+		err          string
+	}{
+		{"ok", "1|2\n3|4\n\n.#\n#.\n", 0, 0, ""},
+		{"no newline at end", "1|2\n\n.#\n#.", 0, 0, ""},
+		{"empty line at end", "1|2\n\n.#\n#.\n\n", 0, 0, ""},
+		{"crlf empty line at end", "1|2\r\n\r\n.#\r\n#.\r\n\r\n", 0, 0, ""},
+		{"crlf", "1|2\r\n3|4\r\n\r\n.#\r\n#.\r\n", 0, 0, ""},
+		{"empty", "", 0, 0, "empty input"},
+		{"trailing space", "1|2\n3|4 \n\n.#\n#.\n", 2, 4, "trailing space"},
+		{"bad line", "1|2\n3-4\n\n.#\n#.\n", 2, 0, "bad line"},
+		{"no separator", "1|2\n3|4\n", 2, 0, "missing the map"},
+		{"leading blank line", "\n1|2\n\n.#\n#.\n", 1, 0, "unexpected blank line"},
+		{"two blank lines", "1|2\n\n\n.#\n#.\n", 3, 0, "unexpected blank line"},
+		{"two empty lines at end", "1|2\n\n.#\n#.\n\n\n", 5, 0, "unexpected blank line after the map"},
+		{"extra section", "1|2\n\n.#\n#.\n\n1|2\n", 5, 0, "unexpected blank line after the map"},
+		{"ragged grid", "1|2\n\n.#\n#\n", 4, 0, "row of length 1"},
+		{"bad cell", "1|2\n\n.#\n#x\n", 4, 2, `unexpected 'x' in the map`},
+		{"too many rows", "1|2\n\n.#\n#.\n..\n", 5, 0, "has 3 lines, want 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDayInput(2024, 1, nil, WithReader(strings.NewReader(tt.input)), WithoutCache())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lines, err := Load(d.Expect(testShape), func(d DayInput) ([]string, error) {
+				return d.ReadLines()
+			})
+
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if strings.Contains(strings.Join(lines, ""), "\r") {
+					t.Errorf("line endings not normalised: %q", lines)
+				}
+				if lines[len(lines)-1] == "" {
+					t.Errorf("empty line at the end not dropped: %q", lines)
+				}
+				return
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("want a parse error, got %v", err)
+			}
+			if parseErr.Line != tt.line || parseErr.Column != tt.column || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("want %q at line %d column %d, got %v", tt.err, tt.line, tt.column, err)
+			}
+		})
+	}
+}
+
+func TestShapeRepeat(t *testing.T) {
+	shape := Shape{{Name: "schematic", Grid: `[#.]`, Repeat: true}}
+
+	if err := shape.check("input", []byte("#.\n.#\n\n##\n..\n\n..\n")); err != nil {
+		t.Error(err)
+	}
+
+	err := shape.check("input", []byte("#.\n\n\n##\n"))
+	if err == nil || !strings.Contains(err.Error(), "input line 3: unexpected blank line") {
+		t.Errorf("want an unexpected blank line on line 3, got %v", err)
+	}
+}
+
+func TestShapeCompilesOnce(t *testing.T) {
+	for _, tt := range []string{"1|2\n\n.#\n#.\n", "3|4\n\n#.\n.#\n"} {
+		if err := testShape.check("input", []byte(tt)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if a, b := compile(`^(?:\d+\|\d+)$`), compile(`^(?:\d+\|\d+)$`); a != b {
+		t.Error("want the pattern compiled once")
+	}
+}
@@ -0,0 +1,74 @@
+package day
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Generator returns a random input for a day, for checking that its
+// variants agree on more inputs than the examples and the real input. Size
+// says how large the input should be, from 1 up, so that disagreements are
+// found on small inputs first. Inputs are valid puzzle inputs: they have
+// the shape of the day's input, and answers that real inputs could have.
+type Generator func(r *rand.Rand, size int) []byte
+
+var generators = make(map[[2]int]Generator)
+
+// RegisterGenerator makes g the generator of random inputs for a day of a
+// year. Like Register, it is meant to be called from the init function of
+// a day's package, and panics when it is called twice for the same day. All
+// variants of a day share its generator.
+func RegisterGenerator(year, day int, g Generator) {
+	key := [2]int{year, day}
+	if _, ok := generators[key]; ok {
+		panic(fmt.Sprintf("day: RegisterGenerator called twice for day %d/%02d", year, day))
+	}
+
+	generators[key] = g
+}
+
+// Generator returns the generator of random inputs for the puzzle's day, or
+// nil when it has none.
+func (p Puzzle) Generator() Generator {
+	return generators[[2]int{p.Year, p.Day}]
+}
+
+// Variants returns the puzzles registered for a day of a year, ordered by
+// variant. Variants are alternate implementations of the same puzzle, and
+// must give the same answers for every input.
+func Variants(year, day int) []Puzzle {
+	var result []Puzzle
+
+	for _, p := range registry {
+		if p.Year == year && p.Day == day {
+			result = append(result, p)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b Puzzle) int {
+		return strings.Compare(a.Variant, b.Variant)
+	})
+
+	return result
+}
+
+// errExamples stops the constructor that Examples calls, before it reads
+// an input.
+var errExamples = errors.New("looking up examples")
+
+// Examples returns the examples embedded in the package of the puzzle, or
+// nil when it has none.
+func (p Puzzle) Examples() fs.FS {
+	var examples fs.FS
+
+	p.New(func(d *DayInput) error {
+		examples = d.examples
+		return errExamples
+	})
+
+	return examples
+}
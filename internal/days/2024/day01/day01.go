@@ -0,0 +1,97 @@
+package day01
+
+import (
+	"embed"
+	"slices"
+	"strings"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "location IDs", Pattern: `\d+ +\d+`}}
+
+type day01 struct {
+	left, right []int
+}
+
+func NewDay01(opts ...day.Option) (day01, error) {
+	input, err := day.NewDayInput(2024, 1, examples, opts...)
+	if err != nil {
+		return day01{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func abs(num int) int {
+	if num < 0 {
+		return -num
+	}
+	return num
+}
+
+func readLine(line string) [2]int {
+	fields := strings.Fields(line)
+
+	return [2]int{conv.MustAtoi(fields[0]), conv.MustAtoi(fields[1])}
+}
+
+func parseInput(input day.DayInput) (day01, error) {
+	pairs, err := day.ParseLines(input, readLine)
+	if err != nil {
+		return day01{}, err
+	}
+
+	var d day01
+
+	for _, pair := range pairs {
+		d.left = append(d.left, pair[0])
+		d.right = append(d.right, pair[1])
+	}
+
+	return d, nil
+}
+
+func histogram(list []int) map[int]int {
+	result := make(map[int]int)
+
+	for _, l := range list {
+		result[l]++
+	}
+
+	return result
+}
+
+func (d day01) Part1() (day.Answer, error) {
+	left := slices.Sorted(slices.Values(d.left))
+	right := slices.Sorted(slices.Values(d.right))
+
+	sum := 0
+
+	for i, l := range left {
+		sum += abs(right[i] - l)
+	}
+
+	return day.Int(sum), nil
+}
+
+func (d day01) Part2() (day.Answer, error) {
+	left := histogram(d.left)
+	right := histogram(d.right)
+
+	sum := 0
+
+	for k, v := range left {
+		sum += k * v * right[k]
+	}
+
+	return day.Int(sum), nil
+}
+
+func init() {
+	day.Register(2024, 1, "", NewDay01)
+}
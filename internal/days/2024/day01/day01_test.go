@@ -0,0 +1,14 @@
+package day01
+
+import (
+	"adventofcode/internal/daytest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay01)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay01)
+}
@@ -1,29 +1,37 @@
-package main
+package day02
 
 import (
-	"os"
-	"path/filepath"
-	"runtime"
+	"embed"
 	"slices"
 	"strings"
 
-	"adventofcode2024/internal/conv"
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "reports", Pattern: `\d+( \d+)*`}}
 
 type day02 struct {
-	day.DayInput
+	reports []report
 }
 
 type report []int
 
-func NewDay02(opts ...day.Option) day02 {
-	return day02{day.NewDayInput(path, opts...)}
+func NewDay02(opts ...day.Option) (day02, error) {
+	input, err := day.NewDayInput(2024, 2, examples, opts...)
+	if err != nil {
+		return day02{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day02, error) {
+	reports, err := day.ParseLines(input, parseReport)
+	return day02{reports}, err
 }
 
 func parseReport(line string) report {
@@ -38,8 +46,9 @@ func parseReport(line string) report {
 }
 
 func (r report) reverse() report {
-	slices.Reverse(r)
-	return r
+	result := slices.Clone(r)
+	slices.Reverse(result)
+	return result
 }
 
 func (r report) isAscending() bool {
@@ -70,38 +79,30 @@ func (r report) isAlmostSafe() bool {
 	return false
 }
 
-func (d day02) Part1() int {
-	lines := d.ReadLines()
-
+func (d day02) Part1() (day.Answer, error) {
 	safe := 0
 
-	for _, line := range lines {
-		report := parseReport(line)
+	for _, report := range d.reports {
 		if report.isSafe() {
 			safe++
 		}
 	}
 
-	return safe
+	return day.Int(safe), nil
 }
 
-func (d day02) Part2() int {
-	lines := d.ReadLines()
-
+func (d day02) Part2() (day.Answer, error) {
 	safe := 0
 
-	for _, line := range lines {
-		report := parseReport(line)
+	for _, report := range d.reports {
 		if report.isAlmostSafe() {
 			safe++
 		}
 	}
 
-	return safe
+	return day.Int(safe), nil
 }
 
-func main() {
-	d := NewDay02(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 2, "", NewDay02)
 }
@@ -0,0 +1,14 @@
+package day02
+
+import (
+	"adventofcode/internal/daytest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay02)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay02)
+}
@@ -0,0 +1,103 @@
+package day03
+
+import (
+	"embed"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "memory"}}
+
+var (
+	mulRE     = regexp.MustCompile(`mul\((\d{1,3}),(\d{1,3})\)`)
+	enabledRE = regexp.MustCompile(`(?s)do\(\).*?don't\(\)`)
+)
+
+type day03 struct {
+	memory string
+}
+
+func NewDay03(opts ...day.Option) (day03, error) {
+	input, err := day.NewDayInput(2024, 3, examples, opts...)
+	if err != nil {
+		return day03{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day03, error) {
+	memory, err := input.ReadInput()
+	return day03{string(memory)}, err
+}
+
+func sumMuls(s string) int {
+	result := 0
+
+	muls := mulRE.FindAllStringSubmatch(s, -1)
+	for _, mul := range muls {
+		result += conv.MustAtoi(mul[1]) * conv.MustAtoi(mul[2])
+	}
+
+	return result
+}
+
+func (d day03) Part1() (day.Answer, error) {
+	return day.Int(sumMuls(d.memory)), nil
+}
+
+func (d day03) Part2() (day.Answer, error) {
+	enabledMuls := enabledRE.FindAllString("do()"+d.memory+"don't()", -1)
+
+	result := 0
+
+	for _, muls := range enabledMuls {
+		result += sumMuls(muls)
+	}
+
+	return day.Int(result), nil
+}
+
+// generate returns corrupted memory: instructions, some of them broken or
+// with numbers that are too long, between random characters.
+func generate(r *rand.Rand, size int) []byte {
+	switches := []string{"do()", "don't()"}
+	pieces := []string{"mul(", "mul", "(", ")", ",", "do(", "don't", " ", "x", "%", "+", "?", "\n"}
+
+	var b strings.Builder
+	for range 4 * size {
+		switch r.IntN(4) {
+		case 0:
+			fmt.Fprintf(&b, "mul(%d,%d)", r.IntN(1200), r.IntN(1200))
+		case 1:
+			b.WriteString(strconv.Itoa(r.IntN(1000)))
+		case 2:
+			b.WriteString(switches[r.IntN(len(switches))])
+		default:
+			b.WriteString(pieces[r.IntN(len(pieces))])
+		}
+	}
+
+	// blank lines are not part of the memory
+	lines := slices.DeleteFunc(strings.Split(b.String(), "\n"), func(line string) bool { return line == "" })
+	if len(lines) == 0 {
+		lines = []string{"x"}
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func init() {
+	day.Register(2024, 3, "", NewDay03)
+	day.RegisterGenerator(2024, 3, generate)
+}
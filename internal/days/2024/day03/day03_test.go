@@ -0,0 +1,14 @@
+package day03
+
+import (
+	"adventofcode/internal/daytest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay03)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay03)
+}
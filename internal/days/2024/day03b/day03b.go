@@ -1,28 +1,31 @@
-package main
+package day03b
 
 import (
 	"bufio"
 	"bytes"
-	"log"
-	"os"
-	"path/filepath"
-	"runtime"
+	"embed"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "memory"}}
 
+// day03b sums the multiplications in a single pass over the input, so
+// both answers are known once the input is parsed.
 type day03b struct {
-	day.DayInput
-	part1, part2 *int
+	part1, part2 int
 }
 
-func NewDay03b(opts ...day.Option) day03b {
-	return day03b{day.NewDayInput(path, opts...), new(int), new(int)}
+func NewDay03b(opts ...day.Option) (day03b, error) {
+	input, err := day.NewDayInput(2024, 3, examples, opts...)
+	if err != nil {
+		return day03b{}, err
+	}
+
+	return day.Load(input.Expect(shape), computeParts)
 }
 
 func parseNumber(data []byte, startAt int) (int, int) {
@@ -76,10 +79,12 @@ func splitAtClosingBracket(data []byte, atEOF bool) (advance int, token []byte,
 	return 0, nil, nil
 }
 
-func (d day03b) computeParts() {
-	file, err := os.Open(d.Input)
+func computeParts(input day.DayInput) (day03b, error) {
+	var d day03b
+
+	file, err := input.Open()
 	if err != nil {
-		log.Fatal(err)
+		return d, err
 	}
 	defer file.Close()
 
@@ -97,30 +102,22 @@ func (d day03b) computeParts() {
 			enabled = 0
 		} else {
 			mul := mul(line)
-			*d.part1 += mul
-			*d.part2 += mul * enabled
+			d.part1 += mul
+			d.part2 += mul * enabled
 		}
 	}
-}
-
-func (d day03b) Part1() int {
-	if *d.part1 == 0 {
-		d.computeParts()
-	}
 
-	return *d.part1
+	return d, scanner.Err()
 }
 
-func (d day03b) Part2() int {
-	if *d.part2 == 0 {
-		d.computeParts()
-	}
-
-	return *d.part2
+func (d day03b) Part1() (day.Answer, error) {
+	return day.Int(d.part1), nil
 }
 
-func main() {
-	d := NewDay03b(day.FromArgs(os.Args[1:]))
+func (d day03b) Part2() (day.Answer, error) {
+	return day.Int(d.part2), nil
+}
 
-	day.Solve(d)
+func init() {
+	day.Register(2024, 3, "b", NewDay03b)
 }
@@ -0,0 +1,34 @@
+package day03b
+
+import (
+	"adventofcode/internal/day"
+	"adventofcode/internal/daytest"
+	"strings"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay03b)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay03b)
+}
+
+func TestReaderPart1(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader("xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))")
+	d, err := NewDay03b(day.WithReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := day.Int(161)
+	got, err := d.Part1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
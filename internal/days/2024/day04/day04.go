@@ -1,22 +1,20 @@
-package main
+package day04
 
 import (
+	"embed"
 	"fmt"
-	"os"
-	"path/filepath"
-	"runtime"
 	"strings"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "word search", Grid: `[XMAS]`}}
 
 type day04 struct {
-	day.DayInput
+	w wordSearch
 }
 
 type wordSearch [][]byte
@@ -36,8 +34,22 @@ var directions = []direction{
 	{-1, 1},  // northeast
 }
 
-func NewDay04(opts ...day.Option) day04 {
-	return day04{day.NewDayInput(path, opts...)}
+func NewDay04(opts ...day.Option) (day04, error) {
+	input, err := day.NewDayInput(2024, 4, examples, opts...)
+	if err != nil {
+		return day04{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day04, error) {
+	lines, err := input.ReadGrid()
+	if err != nil {
+		return day04{}, err
+	}
+
+	return day04{makeWordSearch(lines)}, nil
 }
 
 func (w wordSearch) isMAS(r, c int, dir direction) bool {
@@ -89,9 +101,8 @@ func makeWordSearch(input []string) wordSearch {
 	return result
 }
 
-func (d day04) Part1() int {
-	lines := d.ReadLines()
-	w := makeWordSearch(lines)
+func (d day04) Part1() (day.Answer, error) {
+	w := d.w
 
 	xmas := 0
 
@@ -101,12 +112,11 @@ func (d day04) Part1() int {
 		}
 	}
 
-	return xmas
+	return day.Int(xmas), nil
 }
 
-func (d day04) Part2() int {
-	lines := d.ReadLines()
-	w := makeWordSearch(lines)
+func (d day04) Part2() (day.Answer, error) {
+	w := d.w
 
 	xmas := 0
 
@@ -118,11 +128,9 @@ func (d day04) Part2() int {
 		}
 	}
 
-	return xmas
+	return day.Int(xmas), nil
 }
 
-func main() {
-	d := NewDay04(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 4, "", NewDay04)
 }
@@ -0,0 +1,14 @@
+package day04
+
+import (
+	"adventofcode/internal/daytest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay04)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay04)
+}
@@ -0,0 +1,156 @@
+package day05
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{
+	{Name: "rules", Pattern: `\d+\|\d+`},
+	{Name: "updates", Pattern: `\d+(,\d+)*`},
+}
+
+type day05 struct {
+	rules rules
+	pages []page
+}
+
+type rules map[int]map[int]struct{}
+
+type page []int
+
+func NewDay05(opts ...day.Option) (day05, error) {
+	input, err := day.NewDayInput(2024, 5, examples, opts...)
+	if err != nil {
+		return day05{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+// add adds a rule, X|Y, that page X comes before page Y.
+func (r rules) add(line string) error {
+	s, t, ok := strings.Cut(line, "|")
+	x, err1 := strconv.Atoi(s)
+	y, err2 := strconv.Atoi(t)
+	if !ok || err1 != nil || err2 != nil {
+		return fmt.Errorf("bad rule %q, want X|Y", line)
+	}
+
+	if _, ok := r[x]; !ok {
+		r[x] = make(map[int]struct{})
+	}
+	r[x][y] = struct{}{}
+
+	return nil
+}
+
+func parsePage(line string) (page, error) {
+	var result page
+
+	for p := range strings.SplitSeq(line, ",") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("bad page %q", p)
+		}
+		result = append(result, n)
+	}
+
+	return result, nil
+}
+
+func cmp(rules rules) func(a, b int) int {
+	return func(a, b int) int {
+		if _, ok := rules[a][b]; ok {
+			return -1
+		} else if _, ok := rules[b][a]; ok {
+			return 1
+		} else {
+			return 0
+		}
+	}
+}
+
+func (p page) middle() int {
+	return p[len(p)/2]
+}
+
+func (p page) isSorted(rules rules) bool {
+	return slices.IsSortedFunc(p, cmp(rules))
+}
+
+func (p page) sorted(rules rules) page {
+	result := slices.Clone(p)
+	slices.SortFunc(result, cmp(rules))
+	return result
+}
+
+func parseInput(input day.DayInput) (day05, error) {
+	lines, err := input.ReadLines()
+	if err != nil {
+		return day05{}, err
+	}
+
+	blank := slices.Index(lines, "")
+	if blank == -1 {
+		return day05{}, &day.ParseError{File: input.Name(), Err: errors.New("no blank line between the rules and the pages")}
+	}
+
+	d := day05{rules: make(rules)}
+
+	for i, line := range lines {
+		var err error
+
+		switch {
+		case i < blank:
+			err = d.rules.add(line)
+		case i > blank:
+			var p page
+			p, err = parsePage(line)
+			d.pages = append(d.pages, p)
+		}
+
+		if err != nil {
+			return day05{}, &day.ParseError{File: input.Name(), Line: i + 1, Err: err}
+		}
+	}
+
+	return d, nil
+}
+
+func (d day05) Part1() (day.Answer, error) {
+	sum := 0
+
+	for _, page := range d.pages {
+		if page.isSorted(d.rules) {
+			sum += page.middle()
+		}
+	}
+
+	return day.Int(sum), nil
+}
+
+func (d day05) Part2() (day.Answer, error) {
+	sum := 0
+
+	for _, page := range d.pages {
+		if !page.isSorted(d.rules) {
+			sum += page.sorted(d.rules).middle()
+		}
+	}
+
+	return day.Int(sum), nil
+}
+
+func init() {
+	day.Register(2024, 5, "", NewDay05)
+}
@@ -0,0 +1,14 @@
+package day05
+
+import (
+	"adventofcode/internal/daytest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay05)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay05)
+}
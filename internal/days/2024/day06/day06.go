@@ -1,22 +1,24 @@
-package main
+package day06
 
 import (
 	"bytes"
-	"os"
-	"path/filepath"
-	"runtime"
+	"context"
+	"embed"
+	"errors"
+	"slices"
 	"strings"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "map", Grid: `[.#^]`}}
 
 type day06 struct {
-	day.DayInput
+	patrolMap patrolMap
+	guard     position
 }
 
 type direction [2]int
@@ -37,8 +39,27 @@ var (
 	}
 )
 
-func NewDay06(opts ...day.Option) day06 {
-	return day06{day.NewDayInput(path, opts...)}
+func NewDay06(opts ...day.Option) (day06, error) {
+	input, err := day.NewDayInput(2024, 6, examples, opts...)
+	if err != nil {
+		return day06{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day06, error) {
+	lines, err := input.ReadGrid()
+	if err != nil {
+		return day06{}, err
+	}
+
+	patrolMap, guard := parsePatrolMap(lines)
+	if guard.face == (direction{}) {
+		return day06{}, &day.ParseError{File: input.Name(), Err: errors.New("no guard on the map")}
+	}
+
+	return day06{patrolMap, guard}, nil
 }
 
 func (p *position) rotate() {
@@ -82,10 +103,23 @@ func parsePatrolMap(input []string) (patrolMap, position) {
 	return result, pos
 }
 
-func (p position) visits(m patrolMap) map[direction]struct{} {
+func (m patrolMap) clone() patrolMap {
+	result := make(patrolMap, len(m))
+	for i, row := range m {
+		result[i] = slices.Clone(row)
+	}
+	return result
+}
+
+func (p position) visits(ctx context.Context, m patrolMap) (map[direction]struct{}, error) {
 	visited := make(map[direction]struct{})
 
 	for p.on(m) {
+		// a guard that walks in circles never leaves the map
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		visited[[2]int{p.row, p.column}] = struct{}{}
 
 		for p.blocked(m) {
@@ -95,7 +129,7 @@ func (p position) visits(m patrolMap) map[direction]struct{} {
 		p.move()
 	}
 
-	return visited
+	return visited, nil
 }
 
 func (p position) loop(m patrolMap) bool {
@@ -119,27 +153,40 @@ func (p position) loop(m patrolMap) bool {
 	return false
 }
 
-func (d day06) Part1() int {
-	lines := d.ReadLines()
+func (d day06) Part1() (day.Answer, error) {
+	return d.Part1Context(context.Background())
+}
 
-	patrolMap, guard := parsePatrolMap(lines)
+func (d day06) Part2() (day.Answer, error) {
+	return d.Part2Context(context.Background())
+}
 
-	visited := guard.visits(patrolMap)
+func (d day06) Part1Context(ctx context.Context) (day.Answer, error) {
+	visited, err := d.guard.visits(ctx, d.patrolMap)
+	if err != nil {
+		return day.Answer{}, err
+	}
 
-	return len(visited)
+	return day.Int(len(visited)), nil
 }
 
-func (d day06) Part2() int {
-	lines := d.ReadLines()
+func (d day06) Part2Context(ctx context.Context) (day.Answer, error) {
+	// obstructions are placed on a copy, as the map is shared
+	patrolMap, guard := d.patrolMap.clone(), d.guard
 
-	patrolMap, guard := parsePatrolMap(lines)
-
-	visited := guard.visits(patrolMap)
+	visited, err := guard.visits(ctx, patrolMap)
+	if err != nil {
+		return day.Answer{}, err
+	}
 	delete(visited, direction{guard.row, guard.column})
 
 	obstruct := make(map[direction]struct{})
 
 	for v := range visited {
+		if err := ctx.Err(); err != nil {
+			return day.Answer{}, err
+		}
+
 		patrolMap[v[0]][v[1]] = '#'
 		if guard.loop(patrolMap) {
 			obstruct[v] = struct{}{}
@@ -147,11 +194,9 @@ func (d day06) Part2() int {
 		patrolMap[v[0]][v[1]] = '.'
 	}
 
-	return len(obstruct)
+	return day.Int(len(obstruct)), nil
 }
 
-func main() {
-	d := NewDay06(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 6, "", NewDay06)
 }
@@ -0,0 +1,151 @@
+package day07
+
+import (
+	"embed"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "equations", Pattern: `\d+: \d+( \d+)*`}}
+
+type day07 struct {
+	equations []equation
+}
+
+type equation struct {
+	target   int
+	operands []int
+}
+
+type operator func(int, int) int
+
+func NewDay07(opts ...day.Option) (day07, error) {
+	input, err := day.NewDayInput(2024, 7, examples, opts...)
+	if err != nil {
+		return day07{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day07, error) {
+	equations, err := day.ParseLines(input, parseLine)
+	return day07{equations}, err
+}
+
+func parseLine(line string) equation {
+	r, o, _ := strings.Cut(line, ": ")
+	result := conv.MustAtoi(r)
+
+	ops := strings.Split(o, " ")
+
+	operands := make([]int, len(ops))
+
+	for i, op := range ops {
+		operands[i] = conv.MustAtoi(op)
+	}
+
+	return equation{result, operands}
+}
+
+func add(a, b int) int {
+	return a + b
+}
+
+func mul(a, b int) int {
+	return a * b
+}
+
+func concat(a, b int) int {
+	pow := 10
+	for b >= pow {
+		pow *= 10
+	}
+	return a*pow + b
+}
+
+func valid(target, intermediate int, operands []int, operators []operator) bool {
+	if len(operands) == 0 {
+		return target == intermediate
+	}
+
+	if intermediate > target {
+		return false
+	}
+
+	op, remaining := operands[0], operands[1:]
+	for _, operator := range operators {
+		if valid(target, operator(intermediate, op), remaining, operators) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d day07) Part1() (day.Answer, error) {
+	sum := 0
+
+	for _, e := range d.equations {
+		if valid(e.target, e.operands[0], e.operands[1:], []operator{add, mul}) {
+			sum += e.target
+		}
+	}
+
+	return day.Int(sum), nil
+}
+
+func (d day07) Part2() (day.Answer, error) {
+	sum := 0
+
+	for _, e := range d.equations {
+		if valid(e.target, e.operands[0], e.operands[1:], []operator{add, mul, concat}) {
+			sum += e.target
+		}
+	}
+
+	return day.Int(sum), nil
+}
+
+// generate returns equations of small numbers. Most of them hold with some
+// of the operators, the others have a target that is a little off.
+func generate(r *rand.Rand, size int) []byte {
+	operators := []operator{add, mul, concat}
+
+	var b strings.Builder
+	for range size {
+		operands := make([]string, 1+r.IntN(min(size, 6)))
+		target := 0
+
+		for i := range operands {
+			n := 1 + r.IntN(20)
+			operands[i] = strconv.Itoa(n)
+
+			if i == 0 {
+				target = n
+			} else {
+				target = operators[r.IntN(len(operators))](target, n)
+			}
+		}
+
+		if r.IntN(3) == 0 {
+			target += 1 + r.IntN(10)
+		}
+
+		fmt.Fprintf(&b, "%d: %s\n", target, strings.Join(operands, " "))
+	}
+
+	return []byte(b.String())
+}
+
+func init() {
+	day.Register(2024, 7, "", NewDay07)
+	day.RegisterGenerator(2024, 7, generate)
+}
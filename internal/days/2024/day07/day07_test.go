@@ -0,0 +1,24 @@
+package day07
+
+import (
+	"adventofcode/internal/daytest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay07)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay07)
+}
+
+func TestConcat(t *testing.T) {
+	t.Parallel()
+
+	want := 10
+	got := concat(1, 0)
+	if want != got {
+		t.Errorf("want %d, got %d", want, got)
+	}
+}
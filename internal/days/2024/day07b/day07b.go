@@ -1,33 +1,46 @@
-package main
+package day07b
 
 import (
-	"os"
-	"path/filepath"
-	"runtime"
+	"embed"
 	"strings"
 	"sync"
 	"sync/atomic"
 
-	"adventofcode2024/internal/conv"
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "equations", Pattern: `\d+: \d+( \d+)*`}}
 
 type day07b struct {
-	day.DayInput
+	equations []equation
+}
+
+type equation struct {
+	target   int
+	operands []int
 }
 
 type operator func(int, int) (int, bool)
 
-func NewDay07b(opts ...day.Option) day07b {
-	return day07b{day.NewDayInput(path, opts...)}
+func NewDay07b(opts ...day.Option) (day07b, error) {
+	input, err := day.NewDayInput(2024, 7, examples, opts...)
+	if err != nil {
+		return day07b{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day07b, error) {
+	equations, err := day.ParseLines(input, parseLine)
+	return day07b{equations}, err
 }
 
-func parseLine(line string) (int, []int) {
+func parseLine(line string) equation {
 	r, o, _ := strings.Cut(line, ": ")
 	result := conv.MustAtoi(r)
 
@@ -39,7 +52,7 @@ func parseLine(line string) (int, []int) {
 		operands[i] = conv.MustAtoi(op)
 	}
 
-	return result, operands
+	return equation{result, operands}
 }
 
 func divmod(numerator, denominator int) (int, int) {
@@ -91,19 +104,16 @@ func valid(target int, operands []int, operators []operator) bool {
 }
 
 func (d day07b) sumValid(operators []operator) int {
-	lines := d.ReadLines()
-
 	var sum atomic.Int64
 
 	var wg sync.WaitGroup
 
-	for _, line := range lines {
+	for _, e := range d.equations {
 		wg.Add(1)
 
 		go func() {
-			target, operands := parseLine(line)
-			if valid(target, operands, operators) {
-				sum.Add(int64(target))
+			if valid(e.target, e.operands, operators) {
+				sum.Add(int64(e.target))
 			}
 			wg.Done()
 		}()
@@ -114,16 +124,14 @@ func (d day07b) sumValid(operators []operator) int {
 	return int(sum.Load())
 }
 
-func (d day07b) Part1() int {
-	return d.sumValid([]operator{sub, div})
+func (d day07b) Part1() (day.Answer, error) {
+	return day.Int(d.sumValid([]operator{sub, div})), nil
 }
 
-func (d day07b) Part2() int {
-	return d.sumValid([]operator{sub, div, trimSuffix})
+func (d day07b) Part2() (day.Answer, error) {
+	return day.Int(d.sumValid([]operator{sub, div, trimSuffix})), nil
 }
 
-func main() {
-	d := NewDay07b(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 7, "b", NewDay07b)
 }
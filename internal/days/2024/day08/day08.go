@@ -1,25 +1,33 @@
-package main
+package day08
 
 import (
+	"embed"
 	"maps"
-	"os"
-	"path/filepath"
-	"runtime"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "map", Grid: `[.0-9A-Za-z]`}}
 
 type day08 struct {
-	day.DayInput
+	city city
+}
+
+func NewDay08(opts ...day.Option) (day08, error) {
+	input, err := day.NewDayInput(2024, 8, examples, opts...)
+	if err != nil {
+		return day08{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
 }
 
-func NewDay08(opts ...day.Option) day08 {
-	return day08{day.NewDayInput(path, opts...)}
+func parseInput(input day.DayInput) (day08, error) {
+	lines, err := input.ReadLines()
+	return day08{parseCity(lines)}, err
 }
 
 type location [2]int
@@ -109,28 +117,18 @@ func (c city) antinodes(antinodes antinodesFn) map[location]struct{} {
 	return result
 }
 
-func (d day08) Part1() int {
-	lines := d.ReadLines()
+func (d day08) Part1() (day.Answer, error) {
+	antinodes := d.city.antinodes(d.city.antinodesPart1)
 
-	city := parseCity(lines)
-
-	antinodes := city.antinodes(city.antinodesPart1)
-
-	return len(antinodes)
+	return day.Int(len(antinodes)), nil
 }
 
-func (d day08) Part2() int {
-	lines := d.ReadLines()
-
-	city := parseCity(lines)
+func (d day08) Part2() (day.Answer, error) {
+	antinodes := d.city.antinodes(d.city.antinodesPart2)
 
-	antinodes := city.antinodes(city.antinodesPart2)
-
-	return len(antinodes)
+	return day.Int(len(antinodes)), nil
 }
 
-func main() {
-	d := NewDay08(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 8, "", NewDay08)
 }
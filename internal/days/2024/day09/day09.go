@@ -1,22 +1,22 @@
-package main
+package day09
 
 import (
 	"bytes"
-	"os"
-	"path/filepath"
-	"runtime"
+	"embed"
+	"errors"
+	"fmt"
 	"slices"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "disk map", Pattern: `\d+`, Lines: 1}}
 
 type day09 struct {
-	day.DayInput
+	disk disk
 }
 
 type block struct {
@@ -34,8 +34,32 @@ type disk struct {
 	freeSpace []file
 }
 
-func NewDay09(opts ...day.Option) day09 {
-	return day09{day.NewDayInput(path, opts...)}
+func NewDay09(opts ...day.Option) (day09, error) {
+	input, err := day.NewDayInput(2024, 9, examples, opts...)
+	if err != nil {
+		return day09{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day09, error) {
+	data, err := input.ReadInput()
+	if err != nil {
+		return day09{}, err
+	}
+
+	// the disk map is a single line of digits
+	line := bytes.TrimRight(data, "\n")
+	if len(line) == 0 {
+		return day09{}, &day.ParseError{File: input.Name(), Err: errors.New("empty disk map")}
+	}
+	if i := bytes.IndexFunc(line, func(r rune) bool { return r < '0' || r > '9' }); i != -1 {
+		err := fmt.Errorf("unexpected %q in the disk map", line[i])
+		return day09{}, &day.ParseError{File: input.Name(), Line: 1, Column: i + 1, Err: err}
+	}
+
+	return day09{parseDisk(line)}, nil
 }
 
 func isFree(i int) bool {
@@ -50,7 +74,7 @@ func id(i int) int {
 	return i / 2
 }
 
-func parseInput(input []byte) disk {
+func parseDisk(input []byte) disk {
 	var (
 		blocks    []block
 		files     []file
@@ -75,15 +99,20 @@ func parseInput(input []byte) disk {
 	return disk{blocks, files, freeSpace}
 }
 
+// clone returns a copy of the disk that can be compacted.
+func (d disk) clone() disk {
+	return disk{slices.Clone(d.blocks), slices.Clone(d.files), slices.Clone(d.freeSpace)}
+}
+
 func (d disk) blockCompact() {
 	for l, r := 0, len(d.blocks)-1; ; l, r = l+1, r-1 {
-		// find file
-		for d.blocks[r].free {
+		// find file, none on a disk without files
+		for r >= 0 && d.blocks[r].free {
 			r--
 		}
 
-		// find free block
-		for !d.blocks[l].free {
+		// find free block, none on a full disk
+		for l < len(d.blocks) && !d.blocks[l].free {
 			l++
 		}
 
@@ -135,28 +164,22 @@ func (d disk) checksum() int {
 	return result
 }
 
-func (d day09) Part1() int {
-	input := d.ReadInput()
-
-	disk := parseInput(input)
+func (d day09) Part1() (day.Answer, error) {
+	disk := d.disk.clone()
 
 	disk.blockCompact()
 
-	return disk.checksum()
+	return day.Int(disk.checksum()), nil
 }
 
-func (d day09) Part2() int {
-	input := d.ReadInput()
-
-	disk := parseInput(input)
+func (d day09) Part2() (day.Answer, error) {
+	disk := d.disk.clone()
 
 	disk.fileCompact()
 
-	return disk.checksum()
+	return day.Int(disk.checksum()), nil
 }
 
-func main() {
-	d := NewDay09(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 9, "", NewDay09)
 }
@@ -0,0 +1,181 @@
+package day10
+
+import (
+	"bytes"
+	"embed"
+	"math/rand/v2"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "map", Grid: `[0-9]`}}
+
+type day10 struct {
+	grid       [][]byte
+	trailheads []position
+}
+
+type position struct {
+	x, y int
+}
+
+type direction struct {
+	dx, dy int
+}
+
+func trailheads(grid [][]byte) []position {
+	var result []position
+
+	for x, line := range grid {
+		for y, v := range line {
+			if v == '0' {
+				result = append(result, position{x, y})
+			}
+		}
+	}
+
+	return result
+}
+
+func NewDay10(opts ...day.Option) (day10, error) {
+	input, err := day.NewDayInput(2024, 10, examples, opts...)
+	if err != nil {
+		return day10{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day10, error) {
+	grid, err := input.ReadByteGrid()
+	if err != nil {
+		return day10{}, err
+	}
+
+	trailheads := trailheads(grid)
+
+	return day10{grid, trailheads}, nil
+}
+
+func (p position) to(d direction) position {
+	return position{p.x + d.dx, p.y + d.dy}
+}
+
+func (d day10) height(p position) byte {
+	return d.grid[p.x][p.y]
+}
+
+func (d day10) peak(p position) bool {
+	return d.height(p) == '9'
+}
+
+func (d day10) offGrid(p position) bool {
+	return p.x < 0 || p.x >= len(d.grid) || p.y < 0 || p.y >= len(d.grid[0])
+}
+
+func (d day10) neighbours(p position) []position {
+	var result []position
+
+	for _, dir := range []direction{{0, 1}, {1, 0}, {-1, 0}, {0, -1}} {
+		next := p.to(dir)
+		if d.offGrid(next) {
+			continue
+		}
+
+		if d.height(next) == d.height(p)+1 {
+			result = append(result, next)
+		}
+	}
+
+	return result
+}
+
+func (d day10) peaks(trailhead position) int {
+	todo := []position{trailhead}
+	seen := make(map[position]struct{})
+
+	result := 0
+
+	for len(todo) > 0 {
+		p := todo[0]
+		todo = todo[1:]
+
+		if _, ok := seen[p]; ok {
+			continue
+		}
+
+		seen[p] = struct{}{}
+
+		if d.peak(p) {
+			result++
+		}
+
+		todo = append(todo, d.neighbours(p)...)
+	}
+
+	return result
+}
+
+func (d day10) rating(p position) int {
+	if d.peak(p) {
+		return 1
+	}
+
+	result := 0
+
+	for _, neighbour := range d.neighbours(p) {
+		result += d.rating(neighbour)
+	}
+
+	return result
+}
+
+func (d day10) Part1() (day.Answer, error) {
+	return day.Int(conv.SumFunc(d.trailheads, d.peaks)), nil
+}
+
+func (d day10) Part2() (day.Answer, error) {
+	return day.Int(conv.SumFunc(d.trailheads, d.rating)), nil
+}
+
+// generate returns a map whose heights are the distance to the nearest of
+// a few trailheads, so that trails lead away from them, with some heights
+// replaced by random ones.
+func generate(r *rand.Rand, size int) []byte {
+	rows, cols := 1+size/2+r.IntN(size/2+1), 1+size/2+r.IntN(size/2+1)
+
+	heads := make([]position, 1+r.IntN(3))
+	for i := range heads {
+		heads[i] = position{r.IntN(rows), r.IntN(cols)}
+	}
+
+	grid := make([][]byte, rows)
+
+	for x := range grid {
+		grid[x] = make([]byte, cols)
+
+		for y := range grid[x] {
+			h := 9
+			for _, p := range heads {
+				h = min(h, conv.Abs(x-p.x)+conv.Abs(y-p.y))
+			}
+
+			if r.IntN(12) == 0 {
+				h = r.IntN(10)
+			}
+
+			grid[x][y] = byte('0' + h)
+		}
+	}
+
+	return append(bytes.Join(grid, []byte{'\n'}), '\n')
+}
+
+func init() {
+	day.Register(2024, 10, "", NewDay10)
+	day.RegisterGenerator(2024, 10, generate)
+}
@@ -1,21 +1,19 @@
-package main
+package day10b
 
 import (
+	"embed"
 	"iter"
-	"os"
-	"path/filepath"
-	"runtime"
 	"slices"
 
-	"adventofcode2024/internal/conv"
-	"adventofcode2024/internal/day"
-	"adventofcode2024/internal/grid"
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+	"adventofcode/internal/grid"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "map", Grid: `[0-9]`}}
 
 type day10b struct {
 	grid       grid.Grid[byte]
@@ -36,13 +34,24 @@ func trailheads(g grid.Grid[byte]) []grid.Point {
 	return result
 }
 
-func NewDay10b(opts ...day.Option) day10b {
-	input := day.NewDayInput(path, opts...)
+func NewDay10b(opts ...day.Option) (day10b, error) {
+	input, err := day.NewDayInput(2024, 10, examples, opts...)
+	if err != nil {
+		return day10b{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day10b, error) {
+	grid, err := input.ReadByteGrid()
+	if err != nil {
+		return day10b{}, err
+	}
 
-	grid := input.ReadByteGrid()
 	trailheads := trailheads(grid)
 
-	return day10b{grid, trailheads}
+	return day10b{grid, trailheads}, nil
 }
 
 func (d day10b) up(p grid.Point) iter.Seq[grid.Point] {
@@ -89,16 +98,14 @@ func (d day10b) peaks(trailhead grid.Point) int {
 	return peaks
 }
 
-func (d day10b) Part1() int {
-	return conv.SumFunc(d.trailheads, d.peaks)
+func (d day10b) Part1() (day.Answer, error) {
+	return day.Int(conv.SumFunc(d.trailheads, d.peaks)), nil
 }
 
-func (d day10b) Part2() int {
-	return conv.SumFunc(d.trailheads, d.rating)
+func (d day10b) Part2() (day.Answer, error) {
+	return day.Int(conv.SumFunc(d.trailheads, d.rating)), nil
 }
 
-func main() {
-	d := NewDay10b(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 10, "b", NewDay10b)
 }
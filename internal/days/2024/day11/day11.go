@@ -0,0 +1,171 @@
+package day11
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"strconv"
+	"strings"
+
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "stones", Pattern: `\d+( \d+)*`, Lines: 1}}
+
+var (
+	blinks1 = day.NewParam("blinks1", 25, "number of blinks for part 1").Between(0, 90)
+	blinks2 = day.NewParam("blinks2", 75, "number of blinks for part 2").Between(0, 90)
+)
+
+type day11 struct {
+	count            map[stone]int
+	blinks1, blinks2 int
+}
+
+type stone int
+
+type stones struct {
+	count map[stone]int
+	memo  map[stone][]stone
+}
+
+func NewDay11(opts ...day.Option) (day11, error) {
+	input, err := day.NewDayInput(2024, 11, examples, opts...)
+	if err != nil {
+		return day11{}, err
+	}
+
+	d, err := day.Load(input.Expect(shape), parseInput)
+	d.blinks1, d.blinks2 = input.Param(blinks1), input.Param(blinks2)
+
+	return d, err
+}
+
+func parseInput(input day.DayInput) (day11, error) {
+	lines, err := input.ReadLines()
+	if err != nil {
+		return day11{}, err
+	}
+
+	if len(lines) == 0 {
+		return day11{}, &day.ParseError{File: input.Name(), Err: errors.New("no stones")}
+	}
+
+	// only read first line
+	result := make(map[stone]int)
+
+	for _, f := range strings.Fields(lines[0]) {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return day11{}, &day.ParseError{File: input.Name(), Line: 1, Column: strings.Index(lines[0], f) + 1, Err: err}
+		}
+		result[stone(n)] += 1
+	}
+
+	return day11{count: result}, nil
+}
+
+// stones returns the stones before blinking, with a memo of their own.
+func (d day11) stones() stones {
+	return stones{d.count, make(map[stone][]stone)}
+}
+
+func (s stone) nDigits() int {
+	count := 0
+	for s != 0 {
+		s /= 10
+		count++
+	}
+	return count
+}
+
+func divmod(numerator, denominator int) (int, int) {
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	return quotient, remainder
+}
+
+func (s stone) split() []stone {
+	n := s.nDigits() / 2
+	pow := 1
+	for range n {
+		pow *= 10
+	}
+	q, r := divmod(int(s), pow)
+	return []stone{stone(q), stone(r)}
+}
+
+func (s stone) transform() []stone {
+	switch {
+	case s == stone(0):
+		return []stone{1}
+	case s.nDigits()%2 == 0:
+		return s.split()
+	default:
+		return []stone{s * 2024}
+	}
+}
+
+func (s stones) blink() stones {
+	result := make(map[stone]int)
+
+	for st, c := range s.count {
+		if _, ok := s.memo[st]; !ok {
+			t := st.transform()
+			s.memo[st] = t
+		}
+
+		for _, u := range s.memo[st] {
+			result[u] += c
+		}
+	}
+
+	return stones{result, s.memo}
+}
+
+func (s stones) length() int {
+	l := 0
+	for _, n := range s.count {
+		l += n
+	}
+	return l
+}
+
+// afterBlinks returns the number of stones after n blinks, or the error of
+// ctx once it is done.
+func (d day11) afterBlinks(ctx context.Context, n int) (day.Answer, error) {
+	stones := d.stones()
+
+	for range n {
+		if err := ctx.Err(); err != nil {
+			return day.Answer{}, err
+		}
+
+		stones = stones.blink()
+	}
+
+	return day.Int(stones.length()), nil
+}
+
+func (d day11) Part1() (day.Answer, error) {
+	return d.Part1Context(context.Background())
+}
+
+func (d day11) Part2() (day.Answer, error) {
+	return d.Part2Context(context.Background())
+}
+
+func (d day11) Part1Context(ctx context.Context) (day.Answer, error) {
+	return d.afterBlinks(ctx, d.blinks1)
+}
+
+func (d day11) Part2Context(ctx context.Context) (day.Answer, error) {
+	return d.afterBlinks(ctx, d.blinks2)
+}
+
+func init() {
+	day.Register(2024, 11, "", NewDay11, blinks1, blinks2)
+}
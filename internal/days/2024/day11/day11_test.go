@@ -0,0 +1,51 @@
+package day11
+
+import (
+	"adventofcode/internal/day"
+	"adventofcode/internal/daytest"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay11)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay11)
+}
+
+func TestParamBounds(t *testing.T) {
+	p, _ := day.Lookup("2024/11")
+
+	for _, tt := range []struct {
+		name  string
+		value int
+	}{
+		{"blinks1", 91},
+		{"blinks2", 91},
+	} {
+		if _, err := p.New(day.WithExample("example.txt"), day.WithParam(tt.name, tt.value)); err == nil || !strings.Contains(err.Error(), "parameter "+tt.name) {
+			t.Errorf("%s=%d: want the value refused, got %v", tt.name, tt.value, err)
+		}
+	}
+}
+
+func TestCancel(t *testing.T) {
+	d, err := NewDay11(day.WithExample("example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := d.Part1Context(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("part 1: want the part cancelled, got %v", err)
+	}
+	if _, err := d.Part2Context(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("part 2: want the part cancelled, got %v", err)
+	}
+}
@@ -1,23 +1,21 @@
-package main
+package day12
 
 import (
 	"bytes"
+	"embed"
 	"iter"
 	"maps"
-	"os"
-	"path/filepath"
-	"runtime"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt small.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "garden", Grid: `[A-Z]`}}
 
 type day12 struct {
-	day.DayInput
+	grid grid
 }
 
 type grid [][]byte
@@ -41,8 +39,22 @@ type square struct {
 	nw, ne, se, sw int
 }
 
-func NewDay12(opts ...day.Option) day12 {
-	return day12{day.NewDayInput(path, opts...)}
+func NewDay12(opts ...day.Option) (day12, error) {
+	input, err := day.NewDayInput(2024, 12, examples, opts...)
+	if err != nil {
+		return day12{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day12, error) {
+	lines, err := input.ReadGrid()
+	if err != nil {
+		return day12{}, err
+	}
+
+	return day12{parseGrid(lines)}, nil
 }
 
 func (p plot) to(d direction) plot {
@@ -256,24 +268,14 @@ func (s square) corner() bool {
 	return s.convex() || s.concave()
 }
 
-func (d day12) Part1() int {
-	lines := d.ReadLines()
-
-	grid := parseGrid(lines)
-
-	return grid.costPart1()
+func (d day12) Part1() (day.Answer, error) {
+	return day.Int(d.grid.costPart1()), nil
 }
 
-func (d day12) Part2() int {
-	lines := d.ReadLines()
-
-	grid := parseGrid(lines)
-
-	return grid.costPart2()
+func (d day12) Part2() (day.Answer, error) {
+	return day.Int(d.grid.costPart2()), nil
 }
 
-func main() {
-	d := NewDay12(day.FromArgs(os.Args[1:]))
-
-	day.Solve(d)
+func init() {
+	day.Register(2024, 12, "", NewDay12)
 }
@@ -0,0 +1,114 @@
+package day13
+
+import (
+	"embed"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "machine", Pattern: `Button [AB]: X\+\d+, Y\+\d+|Prize: X=\d+, Y=\d+`, Lines: 3, Repeat: true}}
+
+var (
+	machineRE = regexp.MustCompile(`(?s)Button A: X\+(\d+), Y\+(\d+).*?Button B: X\+(\d+), Y\+(\d+).*?Prize: X=(\d+), Y=(\d+)`)
+)
+
+type day13 struct {
+	machines []machine
+}
+
+type machine struct {
+	xa, ya, xb, yb, xp, yp int
+}
+
+func NewDay13(opts ...day.Option) (day13, error) {
+	input, err := day.NewDayInput(2024, 13, examples, opts...)
+	if err != nil {
+		return day13{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func parseInput(input day.DayInput) (day13, error) {
+	data, err := input.ReadInput()
+	if err != nil {
+		return day13{}, err
+	}
+
+	var (
+		result day13
+		text   = string(data)
+	)
+
+	for _, m := range machineRE.FindAllStringSubmatchIndex(text, -1) {
+		var n [6]int
+
+		for i := range n {
+			start, end := m[2*i+2], m[2*i+3]
+
+			if n[i], err = strconv.Atoi(text[start:end]); err != nil {
+				line := strings.Count(text[:start], "\n")
+				column := start - strings.LastIndexByte(text[:start], '\n')
+				return day13{}, &day.ParseError{File: input.Name(), Line: line + 1, Column: column, Err: err}
+			}
+		}
+
+		result.machines = append(result.machines, machine{n[0], n[1], n[2], n[3], n[4], n[5]})
+	}
+
+	return result, nil
+}
+
+func divmod(numerator, denominator int) (int, int) {
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	return quotient, remainder
+}
+
+func tokens(xa, ya, xb, yb, xp, yp int) (int, int) {
+	b, r := divmod(xa*yp-xp*ya, xa*yb-xb*ya)
+	if r != 0 {
+		return 0, 0
+	}
+
+	a, r := divmod(yp-yb*b, ya)
+	if r != 0 {
+		return 0, 0
+	}
+
+	return a, b
+}
+
+func (d day13) Part1() (day.Answer, error) {
+	result := 0
+
+	for _, m := range d.machines {
+		a, b := tokens(m.xa, m.ya, m.xb, m.yb, m.xp, m.yp)
+		result += 3*a + b
+	}
+
+	return day.Int(result), nil
+}
+
+func (d day13) Part2() (day.Answer, error) {
+	const prizeAddition = 10_000_000_000_000
+
+	result := 0
+
+	for _, m := range d.machines {
+		a, b := tokens(m.xa, m.ya, m.xb, m.yb, m.xp+prizeAddition, m.yp+prizeAddition)
+		result += 3*a + b
+	}
+
+	return day.Int(result), nil
+}
+
+func init() {
+	day.Register(2024, 13, "", NewDay13)
+}
@@ -0,0 +1,58 @@
+package day14
+
+import (
+	"adventofcode/internal/day"
+	"adventofcode/internal/daytest"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay14)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay14)
+}
+
+func TestExamplePart2Cancel(t *testing.T) {
+	t.Parallel()
+	d, err := NewDay14(day.WithExample("example.txt"), day.WithParam("width", 11), day.WithParam("height", 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the example has no Christmas tree, so part 2 looks until cancelled
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := d.Part2Context(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("want the search cancelled, got %v", err)
+	}
+}
+
+func TestExampleNoTree(t *testing.T) {
+	t.Parallel()
+	d, err := NewDay14(day.WithExample("example.txt"), day.WithParam("width", 11), day.WithParam("height", 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the robots are back where they started after 77 seconds at most
+	done := make(chan error, 1)
+	go func() {
+		_, err := d.Part2()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errNoTree) {
+			t.Errorf("want %v, got %v", errNoTree, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("want the search to end without a timeout")
+	}
+}
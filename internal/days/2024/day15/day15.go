@@ -1,19 +1,24 @@
-package main
+package day15
 
 import (
 	"bytes"
+	"embed"
+	"errors"
+	"fmt"
 	"maps"
-	"os"
-	"path/filepath"
-	"runtime"
+	"math/rand/v2"
+	"slices"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed large.txt small.txt
+var examples embed.FS
+
+var shape = day.Shape{
+	{Name: "map", Grid: `[#.O@]`},
+	{Name: "moves", Pattern: `[<>^v]+`},
+}
 
 type day15 struct {
 	gridInput [][]byte
@@ -50,36 +55,64 @@ var (
 	otherHalf = map[byte]direction{'[': {0, 1}, ']': {0, -1}}
 )
 
-func parseInput(lines [][]byte) ([][]byte, []byte) {
-	var result [2][][]byte
+// parseInput splits the lines into the map and the moves, which follow it
+// after a blank line.
+func parseInput(name string, lines [][]byte) ([][]byte, []byte, error) {
+	blank := slices.IndexFunc(lines, func(line []byte) bool { return len(line) == 0 })
+	if blank == -1 {
+		return nil, nil, &day.ParseError{File: name, Err: errors.New("no blank line between the map and the moves")}
+	}
+	if !slices.ContainsFunc(lines[:blank], func(line []byte) bool { return bytes.IndexByte(line, '@') != -1 }) {
+		return nil, nil, &day.ParseError{File: name, Err: errors.New("no robot on the map")}
+	}
 
-	i := 0
-	for _, line := range lines {
-		if len(line) == 0 {
-			i++
-			continue
+	for i, line := range lines[blank+1:] {
+		for j, c := range line {
+			if _, ok := moves[c]; !ok {
+				err := fmt.Errorf("unknown move %q", c)
+				return nil, nil, &day.ParseError{File: name, Line: blank + i + 2, Column: j + 1, Err: err}
+			}
 		}
+	}
+
+	m := bytes.Join(lines[blank+1:], []byte{})
+	return lines[:blank], m, nil
+}
 
-		result[i] = append(result[i], line)
+func NewDay15(opts ...day.Option) (day15, error) {
+	input, err := day.NewDayInput(2024, 15, examples, opts...)
+	if err != nil {
+		return day15{}, err
 	}
 
-	m := bytes.Join(result[1], []byte{})
-	return result[0], m
+	return day.Load(input.Expect(shape), readInput)
 }
 
-func NewDay15(opts ...day.Option) day15 {
-	input := day.NewDayInput(path, opts...)
-	lines := input.ReadByteGrid()
-	grid, moves := parseInput(lines)
+func readInput(input day.DayInput) (day15, error) {
+	lines, err := input.ReadByteGrid()
+	if err != nil {
+		return day15{}, err
+	}
 
-	return day15{grid, moves}
+	grid, moves, err := parseInput(input.Name(), lines)
+	if err != nil {
+		return day15{}, err
+	}
+
+	return day15{grid, moves}, nil
 }
 
 func (p position) to(d direction) position {
 	return position{p.x + d.dx, p.y + d.dy}
 }
 
+// at returns what is at p. Warehouses are meant to be walled in, and off
+// the map is a wall for those that aren't.
 func (w warehouse) at(p position) byte {
+	if p.x < 0 || p.x >= len(w.grid) || p.y < 0 || p.y >= len(w.grid[p.x]) {
+		return '#'
+	}
+
 	return w.grid[p.x][p.y]
 }
 
@@ -227,24 +260,61 @@ func (w warehouse) moveSequence() {
 	}
 }
 
-func (d day15) Part1() int {
+func (d day15) Part1() (day.Answer, error) {
 	w := d.warehousePart1()
 
 	w.moveSequence()
 
-	return w.sumBoxesGPS()
+	return day.Int(w.sumBoxesGPS()), nil
 }
 
-func (d day15) Part2() int {
+func (d day15) Part2() (day.Answer, error) {
 	w := d.warehousePart2()
 
 	w.moveSequence()
 
-	return w.sumBoxesGPS()
+	return day.Int(w.sumBoxesGPS()), nil
 }
 
-func main() {
-	d := NewDay15(day.FromArgs(os.Args[1:]))
+// generate returns a warehouse surrounded by walls, with boxes and walls
+// in it, and a robot with random moves.
+func generate(r *rand.Rand, size int) []byte {
+	rows, cols := 3+r.IntN(size/2+2), 3+r.IntN(size/2+2)
+
+	var b bytes.Buffer
+	robot := position{1 + r.IntN(rows-2), 1 + r.IntN(cols-2)}
+
+	for x := range rows {
+		for y := range cols {
+			switch {
+			case x == 0 || y == 0 || x == rows-1 || y == cols-1:
+				b.WriteByte('#')
+			case (position{x, y}) == robot:
+				b.WriteByte('@')
+			case r.IntN(4) == 0:
+				b.WriteByte('O')
+			case r.IntN(8) == 0:
+				b.WriteByte('#')
+			default:
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	b.WriteByte('\n')
+	for i := range 1 + r.IntN(4*size) {
+		if i > 0 && i%20 == 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteByte("<>^v"[r.IntN(4)])
+	}
+	b.WriteByte('\n')
+
+	return b.Bytes()
+}
 
-	day.Solve(d)
+func init() {
+	day.Register(2024, 15, "", NewDay15)
+	day.RegisterGenerator(2024, 15, generate)
 }
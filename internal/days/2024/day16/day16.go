@@ -0,0 +1,205 @@
+package day16
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"maps"
+	"math"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/grid"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "maze", Grid: `[#.SE]`}}
+
+var (
+	turns = map[direction][2]direction{
+		{0, 1}:  {{-1, 0}, {1, 0}},
+		{1, 0}:  {{0, -1}, {0, 1}},
+		{0, -1}: {{-1, 0}, {1, 0}},
+		{-1, 0}: {{0, -1}, {0, 1}},
+	}
+)
+
+type tile struct {
+	x, y int
+}
+
+type direction struct {
+	dx, dy int
+}
+
+type edge struct {
+	to     state
+	weight int
+}
+
+type state struct {
+	x, y      int
+	direction direction
+}
+
+type day16 struct {
+	grid       [][]byte
+	start, end state
+}
+
+func (e edge) To() state {
+	return e.to
+}
+
+func (e edge) Weight() int {
+	return e.weight
+}
+
+func readInput(d day.DayInput) (day16, error) {
+	lines, err := d.ReadByteGrid()
+	if err != nil {
+		return day16{}, err
+	}
+
+	grid := make([][]byte, len(lines))
+	var start, end state
+	var hasStart, hasEnd bool
+
+	for i, line := range lines {
+		grid[i] = line
+		if j := bytes.IndexByte(line, 'S'); j != -1 {
+			start, hasStart = state{i, j, direction{0, 1}}, true
+		}
+		if j := bytes.IndexByte(line, 'E'); j != -1 {
+			end, hasEnd = state{i, j, direction{}}, true
+		}
+	}
+
+	if !hasStart || !hasEnd {
+		return day16{}, &day.ParseError{File: d.Name(), Err: errors.New("no start or no end in the maze")}
+	}
+
+	return day16{grid, start, end}, nil
+}
+
+func NewDay16(opts ...day.Option) (day16, error) {
+	input, err := day.NewDayInput(2024, 16, examples, opts...)
+	if err != nil {
+		return day16{}, err
+	}
+
+	return day.Load(input.Expect(shape), readInput)
+}
+
+func (d day16) path(prev map[state]map[state]struct{}, e state) map[tile]struct{} {
+	result := map[tile]struct{}{{e.x, e.y}: {}}
+
+	if e == d.start {
+		return result
+	}
+
+	for k := range prev[e] {
+		maps.Copy(result, d.path(prev, k))
+	}
+
+	return result
+}
+
+var errNoPath = errors.New("no path from the start to the end")
+
+func (d day16) allShortestPaths() (int, error) {
+	dist, prev := grid.AllShortestPaths(d.start, d.neighbours)
+
+	end, ok := d.endState(dist)
+	if !ok {
+		return 0, errNoPath
+	}
+
+	return len(d.path(prev, end)), nil
+}
+
+// endState returns the state the end is reached in first, and false when
+// it isn't reached.
+func (d day16) endState(dist map[state]int) (state, bool) {
+	shortest := math.MaxInt
+	var result state
+
+	for dir := range maps.Keys(turns) {
+		end := state{d.end.x, d.end.y, dir}
+		if distEnd, ok := dist[end]; ok && distEnd < shortest {
+			shortest = distEnd
+			result = end
+		}
+	}
+
+	return result, shortest != math.MaxInt
+}
+
+func (d day16) shortestPath() (int, error) {
+	dist, _ := grid.ShortestPath(d.start, d.neighbours)
+
+	end, ok := d.endState(dist)
+	if !ok {
+		return 0, errNoPath
+	}
+
+	return dist[end], nil
+}
+
+func (s state) forward() state {
+	return state{s.x + s.direction.dx, s.y + s.direction.dy, s.direction}
+}
+
+func (s state) turn(d direction) state {
+	return state{s.x, s.y, d}
+}
+
+// scan returns what is next to s in direction dir. Mazes are meant to be
+// walled in, and off the grid is a wall for those that aren't.
+func (d day16) scan(s state, dir direction) byte {
+	x, y := s.x+dir.dx, s.y+dir.dy
+	if x < 0 || x >= len(d.grid) || y < 0 || y >= len(d.grid[x]) {
+		return '#'
+	}
+
+	return d.grid[x][y]
+}
+
+func (d day16) neighbours(s state) []grid.Edge[state] {
+	var result []grid.Edge[state]
+
+	if d.scan(s, s.direction) != '#' {
+		result = append(result, edge{s.forward(), 1})
+	}
+
+	for _, dir := range turns[s.direction] {
+		if d.scan(s, dir) != '#' {
+			result = append(result, edge{s.turn(dir), 1000})
+		}
+	}
+
+	return result
+}
+
+func (d day16) Part1() (day.Answer, error) {
+	n, err := d.shortestPath()
+	if err != nil {
+		return day.Answer{}, err
+	}
+
+	return day.Int(n), nil
+}
+
+func (d day16) Part2() (day.Answer, error) {
+	n, err := d.allShortestPaths()
+	if err != nil {
+		return day.Answer{}, err
+	}
+
+	return day.Int(n), nil
+}
+
+func init() {
+	day.Register(2024, 16, "", NewDay16)
+}
@@ -0,0 +1,312 @@
+package day17
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{
+	{Name: "registers", Pattern: `Register [ABC]: \d+`, Lines: 3},
+	{Name: "program", Pattern: `Program: [0-7](,[0-7])*`, Lines: 1},
+}
+
+type day17 struct {
+	program  []byte
+	register map[byte]int
+}
+
+func parseProgram(line string) ([]byte, error) {
+	p, ok := strings.CutPrefix(line, "Program: ")
+	if !ok {
+		return nil, fmt.Errorf("bad program %q, want Program: followed by numbers", line)
+	}
+
+	var program []byte
+
+	for c := range strings.SplitSeq(p, ",") {
+		if len(c) != 1 || c[0] < '0' || c[0] > '7' {
+			return nil, fmt.Errorf("bad number %q in program, want 0 to 7", c)
+		}
+
+		program = append(program, c[0])
+	}
+
+	return program, nil
+}
+
+func parseRegister(line string, register map[byte]int) error {
+	decl, value, ok := strings.Cut(line, ": ")
+	name, found := strings.CutPrefix(decl, "Register ")
+	if !ok || !found || len(name) != 1 || !strings.Contains("ABC", name) {
+		return fmt.Errorf("bad register %q, want Register A, B or C", line)
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	register[name[0]] = n
+
+	return nil
+}
+
+// parseInput parses the registers, and the program that follows them after
+// a blank line.
+func parseInput(name string, lines []string) ([]byte, map[byte]int, error) {
+	blank := slices.Index(lines, "")
+	if blank == -1 || blank+2 != len(lines) {
+		return nil, nil, &day.ParseError{File: name, Err: errors.New("want registers, a blank line, and a program")}
+	}
+
+	register := make(map[byte]int)
+
+	for i, line := range lines[:blank] {
+		if err := parseRegister(line, register); err != nil {
+			return nil, nil, &day.ParseError{File: name, Line: i + 1, Err: err}
+		}
+	}
+
+	program, err := parseProgram(lines[blank+1])
+	if err != nil {
+		return nil, nil, &day.ParseError{File: name, Line: blank + 2, Err: err}
+	}
+
+	return program, register, nil
+}
+
+func NewDay17(opts ...day.Option) (day17, error) {
+	input, err := day.NewDayInput(2024, 17, examples, opts...)
+	if err != nil {
+		return day17{}, err
+	}
+
+	return day.Load(input.Expect(shape), readInput)
+}
+
+func readInput(input day.DayInput) (day17, error) {
+	lines, err := input.ReadLines()
+	if err != nil {
+		return day17{}, err
+	}
+
+	program, register, err := parseInput(input.Name(), lines)
+	if err != nil {
+		return day17{}, err
+	}
+
+	return day17{program, register}, nil
+}
+
+func (d day17) literalOperand(b byte) int {
+	return int(b - '0')
+}
+
+// errReserved is the error of a program that uses combo operand 7, which
+// is reserved and doesn't appear in valid programs.
+var errReserved = errors.New("reserved combo operand 7")
+
+func (d day17) comboOperand(c byte) (int, error) {
+	switch c {
+	case '0', '1', '2', '3':
+		return d.literalOperand(c), nil
+	case '4', '5', '6':
+		return d.register['A'-'4'+c], nil
+	default:
+		return 0, errReserved
+	}
+}
+
+func (d day17) div(operand, register byte) error {
+	n, err := d.comboOperand(operand)
+	if err != nil {
+		return err
+	}
+
+	// dividing by 2^63 or more leaves nothing, and 1<<n would overflow
+	if n >= 63 {
+		d.register[register] = 0
+		return nil
+	}
+
+	d.register[register] = d.register['A'] >> n
+	return nil
+}
+
+func (d day17) adv(operand byte) error {
+	return d.div(operand, 'A')
+}
+
+func (d day17) bxl(operand byte) {
+	d.register['B'] ^= d.literalOperand(operand)
+}
+
+func (d day17) bst(operand byte) error {
+	n, err := d.comboOperand(operand)
+	d.register['B'] = n % 8
+	return err
+}
+
+func (d day17) jnz(operand byte) int {
+	if d.register['A'] == 0 {
+		return -1
+	}
+
+	return d.literalOperand(operand)
+}
+
+func (d day17) bxc(_ byte) {
+	d.register['B'] ^= d.register['C']
+}
+
+func (d day17) out(operand byte) (byte, error) {
+	n, err := d.comboOperand(operand)
+	return byte(n%8 + '0'), err
+}
+
+func (d day17) bdv(operand byte) error {
+	return d.div(operand, 'B')
+}
+
+func (d day17) cdv(operand byte) error {
+	return d.div(operand, 'C')
+}
+
+// execute runs the program, and returns its output, or the error of ctx
+// once it is done, or errReserved for a reserved operand.
+func (d day17) execute(ctx context.Context) ([]byte, error) {
+	pointer := 0
+	var result []byte
+
+	for pointer < len(d.program)-1 {
+		operand := d.program[pointer+1]
+		var err error
+
+		switch d.program[pointer] {
+		case '0':
+			err = d.adv(operand)
+			pointer += 2
+		case '1':
+			d.bxl(operand)
+			pointer += 2
+		case '2':
+			err = d.bst(operand)
+			pointer += 2
+		case '3':
+			jump := d.jnz(operand)
+			if jump == -1 {
+				pointer += 2
+				break
+			}
+
+			// a program that jumps back may never halt
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			pointer = jump
+		case '4':
+			d.bxc(operand)
+			pointer += 2
+		case '5':
+			var b byte
+			b, err = d.out(operand)
+			result = append(result, b)
+			pointer += 2
+		case '6':
+			err = d.bdv(operand)
+			pointer += 2
+		case '7':
+			err = d.cdv(operand)
+			pointer += 2
+		default:
+			pointer += 2
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: %w", pointer/2, err)
+		}
+	}
+
+	return result, nil
+}
+
+func join(b []byte, c byte) []byte {
+	return bytes.Join(bytes.Split(b, []byte{}), []byte{c})
+
+}
+
+func tail(b []byte, n int) []byte {
+	return b[len(b)-n:]
+}
+
+func (d day17) traceBack(ctx context.Context, pl, a int) (int, error) {
+	if pl == len(d.program) {
+		return a, nil
+	}
+
+	a *= 8
+	pl++
+	for i := a; i < a+1024; i++ {
+		d.register['A'] = i
+		output, err := d.execute(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(output) < pl || !bytes.Equal(tail(d.program, pl), tail(output, pl)) {
+			continue
+		}
+
+		return d.traceBack(ctx, pl, i)
+	}
+
+	return -1, nil
+}
+
+// withRegisters returns the day with a copy of the registers, so that
+// running the program leaves the parsed registers alone.
+func (d day17) withRegisters() day17 {
+	d.register = maps.Clone(d.register)
+	return d
+}
+
+func (d day17) Part1() (day.Answer, error) {
+	return d.Part1Context(context.Background())
+}
+
+func (d day17) Part2() (day.Answer, error) {
+	return d.Part2Context(context.Background())
+}
+
+func (d day17) Part1Context(ctx context.Context) (day.Answer, error) {
+	output, err := d.withRegisters().execute(ctx)
+	if err != nil {
+		return day.Answer{}, err
+	}
+
+	return day.String(string(join(output, ','))), nil
+}
+
+func (d day17) Part2Context(ctx context.Context) (day.Answer, error) {
+	a, err := d.withRegisters().traceBack(ctx, 0, 0)
+	if err != nil {
+		return day.Answer{}, err
+	}
+
+	return day.Int(a), nil
+}
+
+func init() {
+	day.Register(2024, 17, "", NewDay17)
+}
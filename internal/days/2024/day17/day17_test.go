@@ -0,0 +1,122 @@
+package day17
+
+import (
+	"adventofcode/internal/day"
+	"adventofcode/internal/daytest"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay17)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay17)
+}
+
+func Test1Part1(t *testing.T) {
+	t.Parallel()
+	d := day17{[]byte{'2', '6'}, map[byte]int{'A': 0, 'B': 0, 'C': 9}}
+
+	d.execute(t.Context())
+	want := 1
+	if d.register['B'] != want {
+		t.Errorf("want %d, got %d", want, d.register['B'])
+	}
+}
+
+func Test2Part1(t *testing.T) {
+	t.Parallel()
+	d := day17{[]byte{'5', '0', '5', '1', '5', '4'}, map[byte]int{'A': 10, 'B': 0, 'C': 0}}
+
+	want := day.String("0,1,2")
+	got, err := d.Part1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func Test3Part1(t *testing.T) {
+	t.Parallel()
+	d := day17{[]byte{'0', '1', '5', '4', '3', '0'}, map[byte]int{'A': 2024, 'B': 0, 'C': 0}}
+
+	want := day.String("4,2,5,6,7,7,7,7,3,1,0")
+	got, err := d.Part1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+
+	// Part1 runs on a copy of the registers, so run the program again to
+	// look at them
+	d.execute(t.Context())
+	wantA := 0
+	if d.register['A'] != wantA {
+		t.Errorf("want %d, got %d", wantA, d.register['A'])
+	}
+}
+
+func Test4Part1(t *testing.T) {
+	t.Parallel()
+	d := day17{[]byte{'1', '7'}, map[byte]int{'A': 0, 'B': 29, 'C': 0}}
+
+	d.execute(t.Context())
+	want := 26
+	if d.register['B'] != want {
+		t.Errorf("want %d, got %d", want, d.register['B'])
+	}
+}
+
+func Test5Part1(t *testing.T) {
+	t.Parallel()
+	d := day17{[]byte{'4', '0'}, map[byte]int{'A': 0, 'B': 2024, 'C': 43690}}
+
+	d.execute(t.Context())
+	want := 44354
+	if d.register['B'] != want {
+		t.Errorf("want %d, got %d", want, d.register['B'])
+	}
+}
+
+func TestLoopTimeout(t *testing.T) {
+	t.Parallel()
+	// jumps to itself for as long as A isn't 0
+	d := day17{[]byte{'3', '0'}, map[byte]int{'A': 1, 'B': 0, 'C': 0}}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := d.Part1Context(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("part 1: want the program to time out, got %v", err)
+	}
+	if _, err := d.Part2Context(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("part 2: want the search to time out, got %v", err)
+	}
+}
+
+func TestBadOperands(t *testing.T) {
+	t.Parallel()
+
+	// combo operand 7 is reserved
+	d := day17{[]byte("0754"), map[byte]int{'A': 5, 'B': 0, 'C': 0}}
+	if _, err := d.Part1(); !errors.Is(err, errReserved) {
+		t.Errorf("want %v, got %v", errReserved, err)
+	}
+
+	// shifting A by itself divides by 2^64 and more as A grows
+	d = day17{[]byte("0454"), map[byte]int{'A': 70, 'B': 0, 'C': 0}}
+	if got, err := d.Part1(); err != nil || got != day.String("0") {
+		t.Errorf("want 0, got %s, %v", got, err)
+	}
+	if _, err := d.Part2(); err != nil {
+		t.Error(err)
+	}
+}
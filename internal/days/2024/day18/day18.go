@@ -0,0 +1,160 @@
+package day18
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+	"adventofcode/internal/grid"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "bytes", Pattern: `\d+,\d+`}}
+
+var (
+	size   = day.NewParam("size", 71, "width and height of the memory space").Between(1, 1000)
+	fallen = day.NewParam("fallen", 1024, "number of bytes fallen for part 1")
+)
+
+type day18 struct {
+	spots        []spot
+	corrupted    map[spot]bool
+	size, fallen int
+}
+
+type spot struct {
+	r, c int
+}
+
+type direction struct {
+	dr, dc int
+}
+
+func parseSpot(line string) spot {
+	c, r, _ := strings.Cut(line, ",")
+	return spot{conv.MustAtoi(r), conv.MustAtoi(c)}
+}
+
+func corruptedGrid(spots []spot, fallen int) map[spot]bool {
+	corrupted := make(map[spot]bool, len(spots))
+
+	for i, s := range spots {
+		corrupted[s] = i < fallen
+	}
+
+	return corrupted
+}
+
+func NewDay18(opts ...day.Option) (day18, error) {
+	input, err := day.NewDayInput(2024, 18, examples, opts...)
+	if err != nil {
+		return day18{}, err
+	}
+
+	spots, err := day.Load(input.Expect(shape), parseInput)
+	if err != nil {
+		return day18{}, err
+	}
+
+	if input.Param(fallen) > len(spots) {
+		return day18{}, fmt.Errorf("parameter fallen: want at most the %d bytes of the input, got %d", len(spots), input.Param(fallen))
+	}
+
+	corrupted := corruptedGrid(spots, input.Param(fallen))
+
+	return day18{spots, corrupted, input.Param(size), input.Param(fallen)}, nil
+}
+
+func parseInput(input day.DayInput) ([]spot, error) {
+	return day.ParseLines(input, parseSpot)
+}
+
+func (s spot) to(d direction) spot {
+	return spot{s.r + d.dr, s.c + d.dc}
+}
+
+func (s spot) String() string {
+	return fmt.Sprintf("%d,%d", s.c, s.r)
+}
+
+func (d day18) offGrid(s spot) bool {
+	return s.r < 0 || s.r >= d.size || s.c < 0 || s.c >= d.size
+}
+
+func (d day18) shortestPath() int {
+	start := spot{0, 0}
+	end := spot{d.size - 1, d.size - 1}
+
+	dist := map[spot]int{start: 0}
+
+	for p := range grid.Bfs(start, d.neighbours) {
+		if p[0] == start {
+			continue
+		}
+		dist[p[0]] = dist[p[1]] + 1
+		if p[0] == end {
+			return dist[p[0]]
+		}
+	}
+
+	return -1
+}
+
+func (d day18) neighbours(s spot) []spot {
+	var result []spot
+
+	for _, dir := range []direction{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+		to := s.to(dir)
+		if d.offGrid(to) {
+			continue
+		}
+
+		if d.corrupted[to] {
+			continue
+		}
+
+		result = append(result, to)
+	}
+
+	return result
+}
+
+func (d day18) Part1() (day.Answer, error) {
+	return day.Int(d.shortestPath()), nil
+}
+
+func (d day18) Part2() (day.Answer, error) {
+	// the search drops bytes on a copy, so that Part1 can run alongside
+	d.corrupted = maps.Clone(d.corrupted)
+
+	lo, hi := d.fallen, len(d.spots)
+	for lo < hi {
+		t := (lo+hi)/2 + 1
+		for _, s := range d.spots[lo:t] {
+			d.corrupted[s] = true
+		}
+		if d.shortestPath() == -1 {
+			hi = t - 1
+		} else {
+			lo = t
+		}
+		for _, s := range d.spots[lo:t] {
+			d.corrupted[s] = false
+		}
+	}
+	if lo == len(d.spots) {
+		return day.Answer{}, errors.New("no byte cuts off the exit")
+	}
+
+	return day.String(d.spots[lo].String()), nil
+}
+
+func init() {
+	day.Register(2024, 18, "", NewDay18, size, fallen)
+}
@@ -0,0 +1,28 @@
+package day18
+
+import (
+	"adventofcode/internal/day"
+	"adventofcode/internal/daytest"
+	"strings"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay18)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay18)
+}
+
+func TestNoCutOff(t *testing.T) {
+	t.Parallel()
+	d, err := NewDay18(day.WithReader(strings.NewReader("1,1\n")), day.WithParam("size", 3), day.WithParam("fallen", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.Part2(); err == nil || err.Error() != "no byte cuts off the exit" {
+		t.Errorf("want an error for bytes that leave the exit reachable, got %v", err)
+	}
+}
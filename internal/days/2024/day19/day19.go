@@ -0,0 +1,115 @@
+package day19
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{
+	{Name: "patterns", Pattern: `[wubrg]+(, [wubrg]+)*`, Lines: 1},
+	{Name: "designs", Pattern: `[wubrg]+`},
+}
+
+var (
+	boolValue = map[bool]int{false: 0, true: 1}
+)
+
+type day19 struct {
+	patterns, designs []string
+}
+
+type memo1 map[string]bool
+
+type memo2 map[string]int
+
+func parseInput(input day.DayInput) (day19, error) {
+	lines, err := input.ReadLines()
+	if err != nil {
+		return day19{}, err
+	}
+
+	if len(lines) < 2 || lines[1] != "" {
+		return day19{}, &day.ParseError{File: input.Name(), Err: errors.New("want patterns, a blank line, and designs")}
+	}
+
+	patterns := strings.Split(lines[0], ", ")
+	if i := slices.Index(patterns, ""); i != -1 {
+		return day19{}, &day.ParseError{File: input.Name(), Line: 1, Err: fmt.Errorf("empty pattern %d", i+1)}
+	}
+
+	return day19{patterns, lines[2:]}, nil
+}
+
+func NewDay19(opts ...day.Option) (day19, error) {
+	input, err := day.NewDayInput(2024, 19, examples, opts...)
+	if err != nil {
+		return day19{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func (m *memo1) possible(design string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.HasPrefix(design, pattern) {
+			tail := design[len(pattern):]
+
+			if _, ok := (*m)[tail]; !ok {
+				(*m)[tail] = m.possible(tail, patterns)
+			}
+
+			if (*m)[tail] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (m *memo2) countWays(design string, patterns []string) int {
+	result := 0
+
+	for _, pattern := range patterns {
+		if strings.HasPrefix(design, pattern) {
+			tail := design[len(pattern):]
+
+			if _, ok := (*m)[tail]; !ok {
+				(*m)[tail] = m.countWays(tail, patterns)
+			}
+
+			result += (*m)[tail]
+		}
+	}
+
+	return result
+}
+
+func (d day19) Part1() (day.Answer, error) {
+	memo := memo1{"": true}
+
+	return day.Int(conv.SumFunc(d.designs, func(design string) int {
+		return boolValue[memo.possible(design, d.patterns)]
+	})), nil
+}
+
+func (d day19) Part2() (day.Answer, error) {
+	memo := memo2{"": 1}
+
+	return day.Int(conv.SumFunc(d.designs, func(design string) int {
+		return memo.countWays(design, d.patterns)
+	})), nil
+}
+
+func init() {
+	day.Register(2024, 19, "", NewDay19)
+}
@@ -0,0 +1,146 @@
+package day20
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+	"adventofcode/internal/grid"
+)
+
+//go:embed example*.txt small.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "racetrack", Grid: `[#.SE]`}}
+
+var saving = day.NewParam("saving", 100, "picoseconds a cheat must save at least")
+
+type day20 struct {
+	track               [][]byte
+	start, end          grid.Point
+	minSaving, maxCheat int
+}
+
+type cheat struct {
+	start, end grid.Point
+}
+
+func readInput(d day.DayInput) (day20, error) {
+	lines, err := d.ReadByteGrid()
+	if err != nil {
+		return day20{}, err
+	}
+
+	track := make([][]byte, len(lines))
+	var start, end grid.Point
+	var hasStart, hasEnd bool
+
+	for i, line := range lines {
+		track[i] = line
+		if j := bytes.IndexByte(line, 'S'); j != -1 {
+			start, hasStart = grid.Point{X: i, Y: j}, true
+		}
+		if j := bytes.IndexByte(line, 'E'); j != -1 {
+			end, hasEnd = grid.Point{X: i, Y: j}, true
+		}
+	}
+
+	if !hasStart || !hasEnd {
+		return day20{}, &day.ParseError{File: d.Name(), Err: errors.New("no start or no end on the racetrack")}
+	}
+
+	return day20{track: track, start: start, end: end}, nil
+}
+
+func NewDay20(opts ...day.Option) (day20, error) {
+	input, err := day.NewDayInput(2024, 20, examples, opts...)
+	if err != nil {
+		return day20{}, err
+	}
+
+	d, err := day.Load(input.Expect(shape), readInput)
+	d.minSaving = input.Param(saving)
+
+	return d, err
+}
+
+func (d day20) neighbours(from grid.Point) []grid.Point {
+	var result []grid.Point
+
+	for _, dir := range []grid.Direction{{Dx: 0, Dy: 1}, {Dx: 1, Dy: 0}, {Dx: 0, Dy: -1}, {Dx: -1, Dy: 0}} {
+		to := from.To(dir)
+		// racetracks are meant to be walled in, but not all inputs are
+		if to.X < 0 || to.X >= len(d.track) || to.Y < 0 || to.Y >= len(d.track[to.X]) {
+			continue
+		}
+		if d.track[to.X][to.Y] == '#' {
+			continue
+		}
+
+		result = append(result, to)
+	}
+
+	return result
+}
+
+func (d day20) bfs(start grid.Point) map[grid.Point]int {
+	dist := map[grid.Point]int{start: 0}
+
+	for p := range grid.Bfs(start, d.neighbours) {
+		if p[0] == start {
+			continue
+		}
+
+		dist[p[0]] = dist[p[1]] + 1
+	}
+
+	return dist
+}
+
+func (d day20) cheatablePaths(distStart, distEnd map[grid.Point]int, maxCheat, maxLength int) int {
+	result := 0
+
+	for start, sDist := range distStart {
+		for dx := -maxCheat; dx <= maxCheat; dx++ {
+			for dy := -maxCheat + conv.Abs(dx); dy <= maxCheat-conv.Abs(dx); dy++ {
+				end := start.To(grid.Direction{Dx: dx, Dy: dy})
+				if eDist, ok := distEnd[end]; ok {
+					dist := sDist + eDist + conv.Abs(dx) + conv.Abs(dy)
+					if dist <= maxLength {
+						result++
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// cheats returns the number of cheats of at most maxCheat picoseconds that
+// save at least minSaving.
+func (d day20) cheats(maxCheat int) (day.Answer, error) {
+	distStart := d.bfs(d.start)
+	distEnd := d.bfs(d.end)
+
+	shortest, ok := distEnd[d.start]
+	if !ok {
+		return day.Answer{}, errors.New("no path from the start to the end")
+	}
+
+	return day.Int(d.cheatablePaths(distStart, distEnd, maxCheat, shortest-d.minSaving)), nil
+}
+
+func (d day20) Part1() (day.Answer, error) {
+	return d.cheats(2)
+}
+
+func (d day20) Part2() (day.Answer, error) {
+	return d.cheats(20)
+}
+
+func init() {
+	day.Register(2024, 20, "", NewDay20, saving)
+}
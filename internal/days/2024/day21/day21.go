@@ -1,21 +1,30 @@
-package main
+package day21
 
 import (
 	"bytes"
+	"context"
+	"embed"
 	"fmt"
-	"math"
-	"os"
-	"path/filepath"
-	"runtime"
+	"math/big"
 	"slices"
 	"strings"
 
-	"adventofcode2024/internal/conv"
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
 
 	"github.com/cespare/xxhash/v2"
 )
 
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "codes", Pattern: `\d{3}A`}}
+
+var (
+	robots1 = day.NewParam("robots1", 2, "number of robots on directional keypads for part 1").Between(0, 100)
+	robots2 = day.NewParam("robots2", 25, "number of robots on directional keypads for part 2").Between(0, 100)
+)
+
 type keypad [][]byte
 
 type key struct {
@@ -27,7 +36,8 @@ type move struct {
 }
 
 type day21 struct {
-	codes []string
+	codes            []string
+	robots1, robots2 int
 }
 
 type seq struct {
@@ -35,7 +45,7 @@ type seq struct {
 	level int
 }
 
-type memo map[seq]int
+type memo map[seq]*big.Int
 
 type keypadType int
 
@@ -45,9 +55,6 @@ const (
 )
 
 var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-
 	keypads = map[keypadType]keypad{
 		numerical: {
 			{'7', '8', '9'},
@@ -84,12 +91,21 @@ var (
 	}
 )
 
-func NewDay21(opts ...day.Option) day21 {
-	input := day.NewDayInput(path, opts...)
+func NewDay21(opts ...day.Option) (day21, error) {
+	input, err := day.NewDayInput(2024, 21, examples, opts...)
+	if err != nil {
+		return day21{}, err
+	}
+
+	d, err := day.Load(input.Expect(shape), parseInput)
+	d.robots1, d.robots2 = input.Param(robots1), input.Param(robots2)
 
-	codes := input.ReadLines()
+	return d, err
+}
 
-	return day21{codes}
+func parseInput(input day.DayInput) (day21, error) {
+	codes, err := input.ReadLines()
+	return day21{codes: codes}, err
 }
 
 func printKeypadOpts(keypadOpts map[move][]string) {
@@ -151,17 +167,20 @@ func useKeypad(level int) keypadType {
 	return directional
 }
 
-func (m *memo) length(sequence []byte, level, nRobots int) int {
+// length returns the length of the shortest sequence to type sequence on
+// the keypad of level. It is a big.Int, as lengths grow exponentially with
+// the number of robots, and overflow an int for more than 33 of them.
+func (m *memo) length(sequence []byte, level, nRobots int) *big.Int {
 	if level > nRobots {
-		return len(sequence)
+		return big.NewInt(int64(len(sequence)))
 	}
 
 	keypad := useKeypad(level)
 
-	sum := 0
+	sum := new(big.Int)
 	prev := byte('A')
 	for _, ch := range sequence {
-		shortest := math.MaxInt
+		var shortest *big.Int
 		for _, option := range keypad.options(prev, ch) {
 			newSeq := append(option, 'A')
 			xxh := xxhash.Sum64(newSeq)
@@ -170,11 +189,11 @@ func (m *memo) length(sequence []byte, level, nRobots int) int {
 				(*m)[s] = m.length(append(option, 'A'), level+1, nRobots)
 			}
 
-			if (*m)[s] < shortest {
+			if shortest == nil || (*m)[s].Cmp(shortest) < 0 {
 				shortest = (*m)[s]
 			}
 		}
-		sum += shortest
+		sum.Add(sum, shortest)
 		prev = ch
 	}
 
@@ -185,28 +204,38 @@ func codeToInt(code string) int {
 	return conv.MustAtoi(code[:len(code)-1])
 }
 
-func (d day21) Part1() int {
+// complexity returns the sum of the complexities of the codes, or the
+// error of ctx once it is done.
+func (d day21) complexity(ctx context.Context, nRobots int) (day.Answer, error) {
 	memo := memo{}
-	sum := 0
+	sum := new(big.Int)
 	for _, code := range d.codes {
-		length := memo.length([]byte(code), 0, 2)
-		sum += codeToInt(code) * length
+		if err := ctx.Err(); err != nil {
+			return day.Answer{}, err
+		}
+
+		length := memo.length([]byte(code), 0, nRobots)
+		sum.Add(sum, new(big.Int).Mul(length, big.NewInt(int64(codeToInt(code)))))
 	}
-	return sum
+	return day.BigInt(sum), nil
 }
 
-func (d day21) Part2() int {
-	memo := memo{}
-	sum := 0
-	for _, code := range d.codes {
-		length := memo.length([]byte(code), 0, 25)
-		sum += codeToInt(code) * length
-	}
-	return sum
+func (d day21) Part1() (day.Answer, error) {
+	return d.Part1Context(context.Background())
 }
 
-func main() {
-	d := NewDay21(day.FromArgs(os.Args[1:]))
+func (d day21) Part2() (day.Answer, error) {
+	return d.Part2Context(context.Background())
+}
+
+func (d day21) Part1Context(ctx context.Context) (day.Answer, error) {
+	return d.complexity(ctx, d.robots1)
+}
+
+func (d day21) Part2Context(ctx context.Context) (day.Answer, error) {
+	return d.complexity(ctx, d.robots2)
+}
 
-	day.Solve(d)
+func init() {
+	day.Register(2024, 21, "", NewDay21, robots1, robots2)
 }
@@ -0,0 +1,209 @@
+package day22
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "secret numbers", Pattern: `\d+`}}
+
+// part 2 looks for sequences of four price changes, which take four
+// new secret numbers
+var iterations = day.NewParam("iterations", 2000, "number of secret numbers each buyer generates").Between(4, 10000)
+
+type secretNumber int
+
+type monkey struct {
+	secretNumber secretNumber
+	price        int
+	changes      [4]int
+	prices       map[[4]int]int
+}
+
+type day22 struct {
+	secretNumbers []secretNumber
+	iterations    int
+}
+
+func NewDay22(opts ...day.Option) (day22, error) {
+	input, err := day.NewDayInput(2024, 22, examples, opts...)
+	if err != nil {
+		return day22{}, err
+	}
+
+	d, err := day.Load(input.Expect(shape), parseInput)
+	d.iterations = input.Param(iterations)
+
+	return d, err
+}
+
+func parseInput(input day.DayInput) (day22, error) {
+	secretNumbers, err := day.ParseLines(input, parseSecretNumber)
+	return day22{secretNumbers: secretNumbers}, err
+}
+
+func parseSecretNumber(line string) secretNumber {
+	return secretNumber(conv.MustAtoi(line))
+}
+
+func (s secretNumber) mix(value secretNumber) secretNumber {
+	return s ^ value
+}
+
+func (s secretNumber) prune() secretNumber {
+	return s % 16777216
+}
+
+func (s secretNumber) mul(value int) secretNumber {
+	return s * secretNumber(value)
+}
+
+func (s secretNumber) div(value int) secretNumber {
+	return s / secretNumber(value)
+}
+
+func (s secretNumber) step1() secretNumber {
+	return s.mix(s.mul(64)).prune()
+}
+
+func (s secretNumber) step2() secretNumber {
+	return s.mix(s.div(32)).prune()
+}
+
+func (s secretNumber) step3() secretNumber {
+	return s.mix(s.mul(2048)).prune()
+}
+
+func (s secretNumber) next() secretNumber {
+	return s.step1().step2().step3()
+}
+
+func (s secretNumber) loop(i int) secretNumber {
+	result := s
+	for range i {
+		result = result.next()
+	}
+	return result
+}
+
+func (s secretNumber) price() int {
+	return int(s) % 10
+}
+
+func valid(changes [4]int) bool {
+	return changes[0] != -10
+}
+
+func (d day22) monkeys() []monkey {
+	result := make([]monkey, len(d.secretNumbers))
+
+	for i, secretNumber := range d.secretNumbers {
+		result[i] = monkey{secretNumber, secretNumber.price(), [4]int{-10, -10, -10, -10}, make(map[[4]int]int)}
+	}
+
+	return result
+}
+
+func (m *monkey) next() {
+	n := m.secretNumber.next()
+	p := n.price()
+	change := p - m.price
+	m.secretNumber, m.price = n, p
+	m.changes[0], m.changes[1], m.changes[2], m.changes[3] = m.changes[1], m.changes[2], m.changes[3], change
+	if !valid(m.changes) {
+		return
+	}
+
+	if _, ok := m.prices[m.changes]; !ok {
+		m.prices[m.changes] = m.price
+	}
+}
+
+func mergePrices(monkeys []monkey) map[[4]int]int {
+	result := make(map[[4]int]int)
+
+	for _, m := range monkeys {
+		for change, price := range m.prices {
+			result[change] += price
+		}
+	}
+
+	return result
+}
+
+// maxBananas returns the most bananas a sequence of changes gets, or the
+// error of ctx once it is done.
+func (d day22) maxBananas(ctx context.Context) (int, error) {
+	monkeys := d.monkeys()
+
+	for range d.iterations {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		for i := range monkeys {
+			monkeys[i].next()
+		}
+	}
+
+	prices := mergePrices(monkeys)
+
+	return slices.Max(slices.Collect(maps.Values(prices))), nil
+}
+
+func (d day22) Part1() (day.Answer, error) {
+	return d.Part1Context(context.Background())
+}
+
+func (d day22) Part2() (day.Answer, error) {
+	return d.Part2Context(context.Background())
+}
+
+func (d day22) Part1Context(ctx context.Context) (day.Answer, error) {
+	sum := secretNumber(0)
+
+	for _, s := range d.secretNumbers {
+		if err := ctx.Err(); err != nil {
+			return day.Answer{}, err
+		}
+
+		sum += s.loop(d.iterations)
+	}
+
+	return day.Int(int(sum)), nil
+}
+
+func (d day22) Part2Context(ctx context.Context) (day.Answer, error) {
+	n, err := d.maxBananas(ctx)
+	if err != nil {
+		return day.Answer{}, err
+	}
+
+	return day.Int(n), nil
+}
+
+// generate returns the initial secret numbers of a few buyers.
+func generate(r *rand.Rand, size int) []byte {
+	var b strings.Builder
+	for range size {
+		fmt.Fprintln(&b, 1+r.IntN(1<<24-1))
+	}
+
+	return []byte(b.String())
+}
+
+func init() {
+	day.Register(2024, 22, "", NewDay22, iterations)
+	day.RegisterGenerator(2024, 22, generate)
+}
@@ -0,0 +1,128 @@
+package day22b
+
+import (
+	"context"
+	"embed"
+	"maps"
+	"slices"
+
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "secret numbers", Pattern: `\d+`}}
+
+// part 2 looks for sequences of four price changes, which take four
+// new secret numbers
+var iterations = day.NewParam("iterations", 2000, "number of secret numbers each buyer generates").Between(4, 10000)
+
+type day22b struct {
+	secrets    []int
+	iterations int
+}
+
+func NewDay22b(opts ...day.Option) (day22b, error) {
+	input, err := day.NewDayInput(2024, 22, examples, opts...)
+	if err != nil {
+		return day22b{}, err
+	}
+
+	d, err := day.Load(input.Expect(shape), parseInput)
+	d.iterations = input.Param(iterations)
+
+	return d, err
+}
+
+func parseInput(input day.DayInput) (day22b, error) {
+	secrets, err := day.ParseLines(input, conv.MustAtoi)
+	return day22b{secrets: secrets}, err
+}
+
+func nextSecret(s int) int {
+	s ^= (s << 6) & 16777215
+	s ^= (s >> 5) & 16777215
+	return (s ^ (s << 11)) & 16777215
+}
+
+func nextStep(secret, price, index int) (int, int, int) {
+	s := nextSecret(secret)
+	p := s % 10
+	change := p - price
+	return s, p, (index%(19*19*19))*19 + (change + 9)
+}
+
+func loop(s, i int) int {
+	for range i {
+		s = nextSecret(s)
+	}
+	return s
+}
+
+// maxPrice returns the most bananas a sequence of changes gets, or the
+// error of ctx once it is done.
+func (d day22b) maxPrice(ctx context.Context) (int, error) {
+	seen := make(map[int]int)
+	prices := make(map[int]int)
+
+	for i, secret := range d.secrets {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		price := secret % 10
+		index := 0
+
+		for range 3 {
+			secret, price, index = nextStep(secret, price, index)
+		}
+
+		for range d.iterations - 3 {
+			secret, price, index = nextStep(secret, price, index)
+
+			if seen[index] != i+1 {
+				seen[index] = i + 1
+				prices[index] += price
+			}
+		}
+	}
+
+	return slices.Max(slices.Collect(maps.Values(prices))), nil
+}
+
+func (d day22b) Part1() (day.Answer, error) {
+	return d.Part1Context(context.Background())
+}
+
+func (d day22b) Part2() (day.Answer, error) {
+	return d.Part2Context(context.Background())
+}
+
+func (d day22b) Part1Context(ctx context.Context) (day.Answer, error) {
+	sum := 0
+
+	for _, s := range d.secrets {
+		if err := ctx.Err(); err != nil {
+			return day.Answer{}, err
+		}
+
+		sum += loop(s, d.iterations)
+	}
+
+	return day.Int(sum), nil
+}
+
+func (d day22b) Part2Context(ctx context.Context) (day.Answer, error) {
+	n, err := d.maxPrice(ctx)
+	if err != nil {
+		return day.Answer{}, err
+	}
+
+	return day.Int(n), nil
+}
+
+func init() {
+	day.Register(2024, 22, "b", NewDay22b, iterations)
+}
@@ -1,23 +1,20 @@
-package main
+package day23
 
 import (
-	"fmt"
+	"embed"
 	"iter"
 	"maps"
-	"os"
-	"path/filepath"
-	"runtime"
 	"slices"
 	"sort"
 	"strings"
 
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "connections", Pattern: `[a-z]{2}-[a-z]{2}`}}
 
 type connection struct {
 	a, b string
@@ -27,10 +24,20 @@ type day23 struct {
 	connections map[string]map[string]struct{}
 }
 
-func NewDay23(opts ...day.Option) day23 {
-	input := day.NewDayInput(path, opts...)
+func NewDay23(opts ...day.Option) (day23, error) {
+	input, err := day.NewDayInput(2024, 23, examples, opts...)
+	if err != nil {
+		return day23{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
 
-	lines := input.ReadLines()
+func parseInput(input day.DayInput) (day23, error) {
+	lines, err := input.ReadLines()
+	if err != nil {
+		return day23{}, err
+	}
 
 	connections := make(map[string]map[string]struct{})
 
@@ -47,7 +54,7 @@ func NewDay23(opts ...day.Option) day23 {
 		connections[b][a] = struct{}{}
 	}
 
-	return day23{connections}
+	return day23{connections}, nil
 }
 
 func (d day23) networks() iter.Seq[[3]string] {
@@ -122,7 +129,7 @@ func hasStartsWithT(network [3]string) bool {
 	return startsWithT(network[0]) || startsWithT(network[1]) || startsWithT(network[2])
 }
 
-func (d day23) Part1() int {
+func (d day23) Part1() (day.Answer, error) {
 	result := 0
 
 	for network := range d.networks() {
@@ -131,7 +138,7 @@ func (d day23) Part1() int {
 		}
 	}
 
-	return result
+	return day.Int(result), nil
 }
 
 func networkName(network map[string]struct{}) string {
@@ -141,7 +148,7 @@ func networkName(network map[string]struct{}) string {
 	return strings.Join(computers, ",")
 }
 
-func (d day23) Part2() string {
+func (d day23) Part2() (day.Answer, error) {
 	R, P, X := make(map[string]struct{}), make(map[string]struct{}), make(map[string]struct{})
 	for computer := range d.connections {
 		P[computer] = struct{}{}
@@ -153,12 +160,9 @@ func (d day23) Part2() string {
 		return len(a) - len(b)
 	})
 
-	return networkName(max)
+	return day.String(networkName(max)), nil
 }
 
-func main() {
-	d := NewDay23(day.FromArgs(os.Args[1:]))
-
-	fmt.Println(d.Part1())
-	fmt.Println(d.Part2())
+func init() {
+	day.Register(2024, 23, "", NewDay23)
 }
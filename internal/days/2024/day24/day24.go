@@ -1,25 +1,26 @@
-package main
+package day24
 
 import (
-	"bytes"
+	"embed"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"maps"
-	"os"
-	"path/filepath"
-	"runtime"
 	"slices"
 	"sort"
 	"strings"
 
-	"adventofcode2024/internal/conv"
-	"adventofcode2024/internal/day"
+	"adventofcode/internal/conv"
+	"adventofcode/internal/day"
 )
 
-var (
-	_, caller, _, _ = runtime.Caller(0)
-	path            = filepath.Dir(caller)
-)
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{
+	{Name: "wires", Pattern: `\w+: [01]`},
+	{Name: "gates", Pattern: `\w+ (AND|OR|XOR) \w+ -> \w+`},
+}
 
 type wire int
 
@@ -68,50 +69,93 @@ type day24 struct {
 	gates map[[2]string][]gate
 }
 
-func parseWire(s string) wire {
-	return wire(conv.MustAtoi(s))
-}
-
-func parseWires(lines string) map[string]wire {
-	result := make(map[string]wire)
+// checkName checks the name of a wire. The bits of the numbers are on wires
+// named x, y or z and their bit number, which the parts count on.
+func checkName(name string) error {
+	if !strings.ContainsAny(name[:1], "xyz") {
+		return nil
+	}
 
-	for _, w := range strings.Split(strings.TrimSpace(lines), "\n") {
-		name, value, _ := strings.Cut(w, ": ")
-		result[name] = parseWire(value)
+	if len(name) != 3 || name[1] < '0' || name[1] > '9' || name[2] < '0' || name[2] > '9' {
+		return fmt.Errorf("bad wire name %q, want %c and two digits", name, name[0])
 	}
 
-	return result
+	return nil
 }
 
-func parseGates(lines string) map[[2]string][]gate {
-	result := make(map[[2]string][]gate)
+func parseWire(line string, wires map[string]wire) error {
+	name, value, ok := strings.Cut(line, ": ")
+	if !ok || name == "" || (value != "0" && value != "1") {
+		return fmt.Errorf("bad wire %q, want name: 0 or 1", line)
+	}
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	wires[name] = wire(conv.MustAtoi(value))
+	return nil
+}
 
-	for _, g := range strings.Split(strings.TrimSpace(lines), "\n") {
-		input, output, _ := strings.Cut(g, " -> ")
-		i := strings.Split(input, " ")
-		result[[2]string{i[0], i[2]}] = append(result[[2]string{i[0], i[2]}], gate{i[1], output})
+func parseGate(line string, gates map[[2]string][]gate) error {
+	input, output, _ := strings.Cut(line, " -> ")
+	i := strings.Split(input, " ")
+	if len(i) != 3 || output == "" {
+		return fmt.Errorf("bad gate %q, want a OP b -> c", line)
+	}
+	if _, ok := operatorMap[i[1]]; !ok {
+		return fmt.Errorf("unknown operator %q", i[1])
+	}
+	for _, name := range []string{i[0], i[2], output} {
+		if name == "" {
+			return fmt.Errorf("bad gate %q, want a OP b -> c", line)
+		}
+		if err := checkName(name); err != nil {
+			return err
+		}
 	}
 
-	return result
+	gates[[2]string{i[0], i[2]}] = append(gates[[2]string{i[0], i[2]}], gate{i[1], output})
+	return nil
 }
 
-func parseInput(input day.DayInput) (map[string]wire, map[[2]string][]gate) {
-	lines := input.ReadInput()
+// parseInput parses the wires, and the gates that follow them after a
+// blank line.
+func parseInput(input day.DayInput) (day24, error) {
+	lines, err := input.ReadLines()
+	if err != nil {
+		return day24{}, err
+	}
 
-	parts := bytes.Split(lines, []byte{'\n', '\n'})
+	blank := slices.Index(lines, "")
+	if blank == -1 {
+		return day24{}, &day.ParseError{File: input.Name(), Err: errors.New("no blank line between the wires and the gates")}
+	}
 
-	wires := parseWires(string(parts[0]))
-	gates := parseGates(string(parts[1]))
+	d := day24{make(map[string]wire), make(map[[2]string][]gate)}
 
-	return wires, gates
-}
+	for i, line := range lines {
+		switch {
+		case i < blank:
+			err = parseWire(line, d.wires)
+		case i > blank:
+			err = parseGate(line, d.gates)
+		}
+
+		if err != nil {
+			return day24{}, &day.ParseError{File: input.Name(), Line: i + 1, Err: err}
+		}
+	}
 
-func NewDay24(opts ...day.Option) day24 {
-	input := day.NewDayInput(path, opts...)
+	return d, nil
+}
 
-	wires, gates := parseInput(input)
+func NewDay24(opts ...day.Option) (day24, error) {
+	input, err := day.NewDayInput(2024, 24, examples, opts...)
+	if err != nil {
+		return day24{}, err
+	}
 
-	return day24{wires, gates}
+	return day.Load(input.Expect(shape), parseInput)
 }
 
 func maxWire(suffix byte, wires []string) int {
@@ -250,15 +294,6 @@ func (d day24) makeGraph() graph {
 		}
 	}
 
-	fmt.Println("nodes:")
-	for node, label := range nodes {
-		fmt.Printf("[%s] %s\n", node, label)
-	}
-	fmt.Println("edges:")
-	for name, edge := range edges {
-		fmt.Printf("(%s) [%s] -- %s --> [%s]\n", name, edge.from, edge.label, edge.to)
-	}
-
 	return graph{nodes, edges}
 }
 
@@ -273,42 +308,42 @@ func (d day24) value(b byte) int {
 	return result
 }
 
-func (d day24) Part1() int {
-	return d.simulate()
+// withWires returns the day with a copy of the wires, so that simulating
+// the circuit leaves the parsed wires alone.
+func (d day24) withWires() day24 {
+	d.wires = maps.Clone(d.wires)
+	return d
 }
 
-func (d day24) Part2() int {
-	dot := d.makeGraph()
+func (d day24) Part1() (day.Answer, error) {
+	return day.Int(d.withWires().simulate()), nil
+}
 
-	file, err := os.Create("graph.dot")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
+// writeDot writes the circuit as a graph in the DOT language, for looking
+// for the swapped wires of part 2 by eye.
+func (d day24) writeDot(w io.Writer) error {
+	dot := d.makeGraph()
 
-	file.WriteString("digraph {\n    rankdir=\"TB\"\n\n")
+	var b strings.Builder
+	b.WriteString("digraph {\n    rankdir=\"TB\"\n\n")
 	ordered := slices.Collect(maps.Keys(dot.nodes))
 	sort.Strings(ordered)
 	for _, node := range ordered {
-		file.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\"];\n", node, dot.nodes[node]))
+		fmt.Fprintf(&b, "    \"%s\" [label=\"%s\"];\n", node, dot.nodes[node])
 	}
 	for _, edge := range dot.edges {
-		file.WriteString(fmt.Sprintf("    \"%s\" -> \"%s\" [label=\"%s\"];\n", edge.from, edge.to, edge.label))
+		fmt.Fprintf(&b, "    \"%s\" -> \"%s\" [label=\"%s\"];\n", edge.from, edge.to, edge.label)
 	}
-	file.WriteString("}\n")
-
-	file.Sync()
+	b.WriteString("}\n")
 
-	x := d.value('x')
-	y := d.value('y')
-	z := d.value('z')
-	fmt.Printf("%d + %d = %d, adder says: %d\n", x, y, x+y, z)
-
-	return 0
+	_, err := io.WriteString(w, b.String())
+	return err
 }
 
-func main() {
-	d := NewDay24(day.FromArgs(os.Args[1:]))
+func (d day24) Part2() (day.Answer, error) {
+	return day.Int(0), nil
+}
 
-	day.Solve(d)
+func init() {
+	day.Register(2024, 24, "", NewDay24)
 }
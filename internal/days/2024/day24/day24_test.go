@@ -0,0 +1,61 @@
+package day24
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/daytest"
+)
+
+var dot = flag.String("dot", "", "write the circuit of the real input as a graph to this file")
+
+func TestExamples(t *testing.T) {
+	daytest.RunFile(t, NewDay24)
+}
+
+func TestGraph(t *testing.T) {
+	d, err := NewDay24(day.WithExample("example2.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := d.writeDot(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(b.String(), "digraph {") || !strings.Contains(b.String(), `"x00" [label="x00"];`) {
+		t.Errorf("want a graph with a node per input wire, got\n%s", b.String())
+	}
+
+	if *dot == "" {
+		return
+	}
+
+	d, err = NewDay24()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Create(*dot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.writeDot(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d = d.withWires()
+	d.simulate()
+	x, y, z := d.value('x'), d.value('y'), d.value('z')
+	t.Logf("%d + %d = %d, adder says: %d", x, y, x+y, z)
+}
+
+func FuzzParse(f *testing.F) {
+	daytest.Fuzz(f, examples, NewDay24)
+}
@@ -0,0 +1,125 @@
+package day25
+
+import (
+	"embed"
+	"fmt"
+
+	"adventofcode/internal/day"
+)
+
+//go:embed example*.txt
+var examples embed.FS
+
+var shape = day.Shape{{Name: "schematic", Grid: `[#.]`, Repeat: true}}
+
+type day25 struct {
+	locks, keys [][5]int
+	height      int
+}
+
+// parseInput parses the schematics, which are separated by blank lines.
+// All of them are 5 pins wide, and have the same height.
+func parseInput(input day.DayInput) (day25, error) {
+	lines, err := input.ReadLines()
+	if err != nil {
+		return day25{}, err
+	}
+
+	var (
+		locks, keys [][5]int
+		height      = -1
+	)
+
+	for start := 0; start < len(lines); {
+		end := start
+		for end < len(lines) && lines[end] != "" {
+			if len(lines[end]) != 5 {
+				err := fmt.Errorf("schematic row of width %d, want 5", len(lines[end]))
+				return day25{}, &day.ParseError{File: input.Name(), Line: end + 1, Err: err}
+			}
+			end++
+		}
+
+		schematic := lines[start:end]
+		if height == -1 {
+			height = len(schematic) - 1
+		}
+		if len(schematic) == 0 || len(schematic)-1 != height {
+			err := fmt.Errorf("schematic of height %d, want %d", len(schematic)-1, height)
+			return day25{}, &day.ParseError{File: input.Name(), Line: start + 1, Err: err}
+		}
+
+		var (
+			pins   [5]int
+			isLock bool
+		)
+		for i, line := range schematic {
+			if i == 0 {
+				isLock = line == "#####"
+				continue
+			}
+			for j, c := range []byte(line) {
+				if isLock && c == '#' {
+					pins[j] = i
+				}
+				if !isLock && c == '.' {
+					pins[j] = i
+				}
+			}
+		}
+		if isLock {
+			locks = append(locks, pins)
+		} else {
+			for i, pin := range pins {
+				pins[i] = height - pin - 1
+			}
+			keys = append(keys, pins)
+		}
+
+		start = end + 1
+	}
+
+	return day25{locks, keys, height}, nil
+}
+
+func NewDay25(opts ...day.Option) (day25, error) {
+	input, err := day.NewDayInput(2024, 25, examples, opts...)
+	if err != nil {
+		return day25{}, err
+	}
+
+	return day.Load(input.Expect(shape), parseInput)
+}
+
+func fits(lock, key [5]int, height int) bool {
+	for i := range 5 {
+		if lock[i]+key[i] >= height {
+			return false
+		}
+	}
+	return true
+}
+
+func (d day25) nFitting() int {
+	result := 0
+	for _, lock := range d.locks {
+		for _, key := range d.keys {
+			if fits(lock, key, d.height) {
+				result++
+			}
+		}
+	}
+	return result
+}
+
+func (d day25) Part1() (day.Answer, error) {
+	return day.Int(d.nFitting()), nil
+}
+
+func (d day25) Part2() (day.Answer, error) {
+	return day.Int(0), nil
+}
+
+func init() {
+	day.Register(2024, 25, "", NewDay25)
+}
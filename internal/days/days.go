@@ -0,0 +1,35 @@
+// Package days links every solution into the day registry.
+package days
+
+import (
+	_ "adventofcode/internal/days/2024/day01"
+	_ "adventofcode/internal/days/2024/day02"
+	_ "adventofcode/internal/days/2024/day03"
+	_ "adventofcode/internal/days/2024/day03b"
+	_ "adventofcode/internal/days/2024/day04"
+	_ "adventofcode/internal/days/2024/day05"
+	_ "adventofcode/internal/days/2024/day06"
+	_ "adventofcode/internal/days/2024/day07"
+	_ "adventofcode/internal/days/2024/day07b"
+	_ "adventofcode/internal/days/2024/day08"
+	_ "adventofcode/internal/days/2024/day09"
+	_ "adventofcode/internal/days/2024/day10"
+	_ "adventofcode/internal/days/2024/day10b"
+	_ "adventofcode/internal/days/2024/day11"
+	_ "adventofcode/internal/days/2024/day12"
+	_ "adventofcode/internal/days/2024/day13"
+	_ "adventofcode/internal/days/2024/day14"
+	_ "adventofcode/internal/days/2024/day15"
+	_ "adventofcode/internal/days/2024/day15b"
+	_ "adventofcode/internal/days/2024/day16"
+	_ "adventofcode/internal/days/2024/day17"
+	_ "adventofcode/internal/days/2024/day18"
+	_ "adventofcode/internal/days/2024/day19"
+	_ "adventofcode/internal/days/2024/day20"
+	_ "adventofcode/internal/days/2024/day21"
+	_ "adventofcode/internal/days/2024/day22"
+	_ "adventofcode/internal/days/2024/day22b"
+	_ "adventofcode/internal/days/2024/day23"
+	_ "adventofcode/internal/days/2024/day24"
+	_ "adventofcode/internal/days/2024/day25"
+)
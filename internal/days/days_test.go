@@ -0,0 +1,66 @@
+package days
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"adventofcode/internal/answers/answerstest"
+	"adventofcode/internal/day"
+	"adventofcode/internal/difftest"
+)
+
+func TestAnswers(t *testing.T) {
+	answerstest.Run(t, day.Puzzles())
+}
+
+// TestVariants checks that the alternate implementations of a day agree, on
+// the examples, the real input if there is one, and random inputs.
+func TestVariants(t *testing.T) {
+	c := difftest.Checker{Runs: 200, Timeout: 10 * time.Second}
+	if testing.Short() {
+		c.Runs = 20
+	}
+
+	for _, puzzles := range difftest.Groups(day.Puzzles()) {
+		t.Run(puzzles[0].Name(), func(t *testing.T) {
+			t.Parallel()
+
+			r, err := c.Check(t.Context(), puzzles)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if r.Examples == 0 || r.Random == 0 {
+				t.Errorf("want examples and random inputs, got %d examples and %d random inputs", r.Examples, r.Random)
+			}
+
+			for _, d := range r.Disagreements {
+				t.Errorf("%v\ninput:\n%s", d, d.Data)
+			}
+		})
+	}
+}
+
+func BenchmarkPuzzles(b *testing.B) {
+	inputs, err := day.InputsDir("")
+	if err != nil {
+		b.Skip(err)
+	}
+
+	for _, p := range day.Puzzles() {
+		b.Run(p.Name(), func(b *testing.B) {
+			if _, err := os.Stat(day.InputPath(inputs, p.Year, p.Day)); err != nil {
+				b.Skip("no input")
+			}
+
+			for b.Loop() {
+				for part := 1; part <= 2; part++ {
+					if _, err := p.Part(b.Context(), part); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Package daytest checks the answers of a day for its examples. The cases
+// are either a table in the test, or kept in a file next to the examples,
+// expected.txt, with a line per part of an example:
+//
+//	# example    part  answer                 parameters
+//	example.txt  1     140
+//	example.txt  2     "4,6,3,5,6,3,5,2,1,0"
+//	small.txt    1     22                     size=7 fallen=12
+//
+// Answers that are strings are quoted, so that they are told apart from
+// numbers. Running the tests with -update writes the answers that the day
+// gives into the file, also for lines that have no answer yet.
+//
+// Fuzz checks that a day copes with any input, seeded with the examples.
+package daytest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"adventofcode/internal/day"
+)
+
+// File is the name of the file with expected answers, in the directory of
+// the day's package.
+const File = "expected.txt"
+
+var update = flag.Bool("update", false, "write the answers the days give into "+File)
+
+// Case is a part of an example, and the answer to expect for it.
+type Case struct {
+	Example string
+	Params  map[string]int
+	Part    int
+	Want    day.Answer
+	// missing is set for lines in File without an answer.
+	missing bool
+	// line is the index of the line in File the case was read from.
+	line int
+}
+
+// Name returns the name of the subtest for the case, e.g.
+// example.txt/size=7/part1.
+func (c Case) Name() string {
+	var b strings.Builder
+
+	b.WriteString(c.Example)
+	for _, name := range slices.Sorted(maps.Keys(c.Params)) {
+		fmt.Fprintf(&b, "/%s=%d", name, c.Params[name])
+	}
+	fmt.Fprintf(&b, "/part%d", c.Part)
+
+	return b.String()
+}
+
+// Run checks every case in a parallel subtest, creating the day with
+// newDay.
+func Run[D day.Day](t *testing.T, newDay func(opts ...day.Option) (D, error), cases ...Case) {
+	t.Helper()
+
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := solve(t, newDay, c)
+			if err != nil {
+				t.Fatalf("%s part %d: %v", c.Example, c.Part, err)
+			}
+
+			if c.missing {
+				t.Fatalf("%s part %d: no answer in %s, got %s; run the test with -update to add it",
+					c.Example, c.Part, File, Format(got))
+			}
+
+			if got != c.Want {
+				t.Errorf("%s part %d: want %s, got %s", c.Example, c.Part, Format(c.Want), Format(got))
+			}
+		})
+	}
+}
+
+// RunFile checks the cases in File like Run does. With -update, it writes
+// the answers that the day gives into File instead.
+func RunFile[D day.Day](t *testing.T, newDay func(opts ...day.Option) (D, error)) {
+	t.Helper()
+
+	lines, cases, err := ReadFile(File)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !*update {
+		Run(t, newDay, cases...)
+		return
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			got, err := solve(t, newDay, c)
+			if err != nil {
+				t.Fatalf("%s part %d: %v", c.Example, c.Part, err)
+			}
+
+			// lines that don't change are left as they are
+			if c.missing || got != c.Want {
+				t.Logf("%s part %d: updated to %s", c.Example, c.Part, Format(got))
+
+				c.Want, c.missing = got, false
+				lines[c.line] = formatCase(c)
+			}
+		})
+	}
+
+	data := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(File, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func solve[D day.Day](t *testing.T, newDay func(opts ...day.Option) (D, error), c Case) (day.Answer, error) {
+	opts := []day.Option{day.WithExample(c.Example)}
+	for name, value := range c.Params {
+		opts = append(opts, day.WithParam(name, value))
+	}
+
+	d, err := newDay(opts...)
+	if err != nil {
+		return day.Answer{}, err
+	}
+
+	return day.SolvePart(t.Context(), d, c.Part)
+}
+
+// ReadFile reads the cases in an expectations file. It also returns the
+// lines of the file, for writing it back with updated answers.
+func ReadFile(name string) ([]string, []Case, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	var (
+		lines []string
+		cases []Case
+	)
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		c, err := parseCase(text)
+		if err != nil {
+			return nil, nil, &day.ParseError{File: name, Line: len(lines), Err: err}
+		}
+
+		c.line = len(lines) - 1
+		cases = append(cases, c)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return lines, cases, nil
+}
+
+// parseCase parses a line of an expectations file: the example, the part,
+// the answer unless it is missing, and parameters.
+func parseCase(line string) (Case, error) {
+	example, rest := cutField(line)
+	partText, rest := cutField(rest)
+
+	part, err := strconv.Atoi(partText)
+	if err != nil || part < 1 || part > 2 {
+		return Case{}, fmt.Errorf("bad part %q", partText)
+	}
+
+	c := Case{Example: example, Part: part, missing: true}
+
+	if first, _ := cutField(rest); strings.HasPrefix(first, `"`) || (first != "" && !strings.Contains(first, "=")) {
+		answer, n, err := parseAnswer(rest)
+		if err != nil {
+			return Case{}, err
+		}
+
+		c.Want, c.missing = answer, false
+		rest = rest[n:]
+	}
+
+	for _, field := range strings.Fields(rest) {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Case{}, fmt.Errorf("bad parameter %q, want name=value", field)
+		}
+
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return Case{}, fmt.Errorf("bad value for parameter %s: %q", name, value)
+		}
+
+		if c.Params == nil {
+			c.Params = make(map[string]int)
+		}
+		c.Params[name] = v
+	}
+
+	return c, nil
+}
+
+// cutField returns the first field of s, and what follows it.
+func cutField(s string) (string, string) {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+
+	i := strings.IndexFunc(s, unicode.IsSpace)
+	if i == -1 {
+		return s, ""
+	}
+
+	return s[:i], strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+}
+
+// parseAnswer parses the answer at the start of s, and returns how much of
+// s it took.
+func parseAnswer(s string) (day.Answer, int, error) {
+	if strings.HasPrefix(s, `"`) {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return day.Answer{}, 0, fmt.Errorf("bad answer %s: %w", s, err)
+		}
+
+		text, err := strconv.Unquote(quoted)
+		return day.String(text), len(quoted), err
+	}
+
+	field := strings.Fields(s)[0]
+
+	n, ok := new(big.Int).SetString(field, 10)
+	if !ok {
+		return day.Answer{}, 0, fmt.Errorf("bad answer %q, quote answers that are strings", field)
+	}
+
+	return day.BigInt(n), len(field), nil
+}
+
+// Format returns an answer the way it is written in an expectations file.
+func Format(a day.Answer) string {
+	if a.Kind() == day.StringKind {
+		return strconv.Quote(a.String())
+	}
+
+	return a.String()
+}
+
+func formatCase(c Case) string {
+	fields := []string{c.Example, strconv.Itoa(c.Part)}
+
+	if !c.missing {
+		fields = append(fields, Format(c.Want))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Params)) {
+		fields = append(fields, fmt.Sprintf("%s=%d", name, c.Params[name]))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// FuzzTimeout limits the time Fuzz gives a part. Parts that take longer
+// are left running, and don't fail the test: inputs on which a part runs for
+// long, or forever, are only bad inputs.
+var FuzzTimeout = 100 * time.Millisecond
+
+// Fuzz checks that newDay either parses an input or returns an error, and
+// that both parts of the days it parses either answer or return an error;
+// neither may panic. The corpus is seeded with every file in examples. Every
+// input is tried with the default parameters, and with each set of
+// parameters of the cases in File, as defaults that don't fit the examples
+// would fail every input. Days are created without the model cache, which
+// would turn a panic into an error.
+func Fuzz[D day.Day](f *testing.F, examples fs.FS, newDay func(opts ...day.Option) (D, error)) {
+	f.Helper()
+
+	err := fs.WalkDir(examples, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(examples, path)
+		f.Add(data)
+		return err
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	params, err := fuzzParams()
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, p := range params {
+			opts := []day.Option{day.WithReader(bytes.NewReader(data)), day.WithoutCache()}
+			for name, value := range p {
+				opts = append(opts, day.WithParam(name, value))
+			}
+
+			d, err := newDay(opts...)
+			if err != nil {
+				if err.Error() == "" {
+					t.Errorf("empty error for %q with %v", data, p)
+				}
+				continue
+			}
+
+			for part := 1; part <= 2; part++ {
+				ctx, cancel := context.WithTimeout(t.Context(), FuzzTimeout)
+				_, err := day.SolvePart(ctx, d, part)
+				cancel()
+
+				if errors.Is(err, day.ErrPanic) {
+					t.Errorf("part %d of %q with %v: %v", part, data, p, err)
+				}
+			}
+		}
+	})
+}
+
+// fuzzParams returns the sets of parameters Fuzz tries: none, for the
+// defaults, and those of the cases in File, if there is one, each once.
+func fuzzParams() ([]map[string]int, error) {
+	result := []map[string]int{nil}
+
+	_, cases, err := ReadFile(File)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, c := range cases {
+		if len(c.Params) > 0 && !slices.ContainsFunc(result, func(p map[string]int) bool { return maps.Equal(p, c.Params) }) {
+			result = append(result, c.Params)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,175 @@
+package daytest
+
+import (
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"adventofcode/internal/day"
+)
+
+// lines is a day that counts the lines of its input in part 1, and joins
+// them in part 2. Its factor parameter multiplies the count.
+type lines struct {
+	lines  []string
+	factor int
+}
+
+var (
+	examples = fstest.MapFS{
+		"example.txt": {Data: []byte("a\nb\nc\n")},
+	}
+	factor = day.NewParam("factor", 1, "factor of the count")
+)
+
+func newLines(opts ...day.Option) (lines, error) {
+	input, err := day.NewDayInput(2024, 1, examples, opts...)
+	if err != nil {
+		return lines{}, err
+	}
+
+	l, err := input.ReadLines()
+	return lines{l, input.Param(factor)}, err
+}
+
+func (l lines) Part1() (day.Answer, error) {
+	return day.Int(l.factor * len(l.lines)), nil
+}
+
+func (l lines) Part2() (day.Answer, error) {
+	return day.String(strings.Join(l.lines, ",")), nil
+}
+
+func TestRun(t *testing.T) {
+	Run(t, newLines,
+		Case{Example: "example.txt", Part: 1, Want: day.Int(3)},
+		Case{Example: "example.txt", Part: 1, Params: map[string]int{"factor": 2}, Want: day.Int(6)},
+		Case{Example: "example.txt", Part: 2, Want: day.String("a,b,c")},
+	)
+}
+
+func TestParseCase(t *testing.T) {
+	tests := []struct {
+		line string
+		want Case
+		err  string
+	}{
+		{"example.txt 1 140", Case{Example: "example.txt", Part: 1, Want: day.Int(140)}, ""},
+		{"example.txt  2  \"4,6,3\"", Case{Example: "example.txt", Part: 2, Want: day.String("4,6,3")}, ""},
+		{`small.txt 1 "a b" size=7`, Case{Example: "small.txt", Part: 1, Want: day.String("a b"), Params: map[string]int{"size": 7}}, ""},
+		{"small.txt 1 22 size=7 fallen=12", Case{Example: "small.txt", Part: 1, Want: day.Int(22), Params: map[string]int{"size": 7, "fallen": 12}}, ""},
+		{"small.txt 2 size=7", Case{Example: "small.txt", Part: 2, Params: map[string]int{"size": 7}, missing: true}, ""},
+		{"example.txt 1", Case{Example: "example.txt", Part: 1, missing: true}, ""},
+		{"example.txt 1 99999999999999999999", Case{Example: "example.txt", Part: 1, Want: day.BigInt(bigInt("99999999999999999999"))}, ""},
+		{"example.txt", Case{}, "bad part"},
+		{"example.txt 3 1", Case{}, "bad part"},
+		{"example.txt 1 4,6,3", Case{}, "quote answers that are strings"},
+		{"example.txt 1 1 size", Case{}, "want name=value"},
+		{"example.txt 1 1 size=x", Case{}, "bad value"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCase(tt.line)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%q: want error %q, got %v", tt.line, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.line, err)
+			continue
+		}
+
+		if got.Name() != tt.want.Name() || got.Want != tt.want.Want || got.missing != tt.want.missing {
+			t.Errorf("%q: want %+v, got %+v", tt.line, tt.want, got)
+		}
+	}
+}
+
+func TestRunFileUpdate(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	const before = `# example part answer
+example.txt  1  3
+example.txt  2  "a,c"
+example.txt  1  factor=5
+`
+	if err := os.WriteFile(File, []byte(before), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	*update = true
+	defer func() { *update = false }()
+
+	RunFile(t, newLines)
+
+	got, err := os.ReadFile(File)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the right answer keeps its layout
+	want := `# example part answer
+example.txt  1  3
+example.txt 2 "a,b,c"
+example.txt 1 15 factor=5
+`
+	if string(got) != want {
+		t.Errorf("want\n%s\ngot\n%s", want, got)
+	}
+
+	*update = false
+	RunFile(t, newLines)
+}
+
+func TestReadFileError(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := os.WriteFile(File, []byte("# cases\nexample.txt one 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := ReadFile(File)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("want an error on line 2, got %v", err)
+	}
+}
+
+func TestFuzzParams(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	// without File, only the defaults
+	if params, err := fuzzParams(); err != nil || len(params) != 1 || params[0] != nil {
+		t.Errorf("want only the defaults, got %v, %v", params, err)
+	}
+
+	const cases = `example.txt  1  3
+example.txt  2  "a,b,c"
+example.txt  1  15  factor=5
+example.txt  2  "a,b,c"  factor=5
+example.txt  1  6  factor=2
+`
+	if err := os.WriteFile(File, []byte(cases), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := fuzzParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 3 || params[0] != nil || params[1]["factor"] != 5 || params[2]["factor"] != 2 {
+		t.Errorf("want the defaults, factor=5 and factor=2, got %v", params)
+	}
+}
+
+func bigInt(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func FuzzLines(f *testing.F) {
+	Fuzz(f, examples, newLines)
+}
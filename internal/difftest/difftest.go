@@ -0,0 +1,313 @@
+// Package difftest checks that the variants of a puzzle, its alternate
+// implementations, agree. They are compared on the examples of every
+// variant, on the real input, and on random inputs from the generator of
+// their day, see day.RegisterGenerator. A disagreement on a random input is
+// shrunk to a small input that still shows it, for debugging.
+package difftest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/secrets"
+)
+
+// MaxSize is the size of the last random inputs of a check, see
+// day.Generator.
+const MaxSize = 20
+
+// Checker compares the variants of puzzles.
+type Checker struct {
+	// Runs is the number of random inputs to compare on.
+	Runs int
+	// Seed seeds the random inputs, which are the same for the same seed.
+	Seed uint64
+	// Timeout limits the time a part may take on an input, without a
+	// limit when it is zero.
+	Timeout time.Duration
+	// InputsDir is the inputs directory with the real input, or empty for
+	// the default.
+	InputsDir string
+}
+
+// Report is the outcome of a check of the variants of a puzzle.
+type Report struct {
+	Puzzles []day.Puzzle
+	// Examples is the number of different examples compared on.
+	Examples int
+	// Input is set when there was a real input to compare on.
+	Input bool
+	// Random is the number of random inputs compared on.
+	Random int
+	// Disagreements holds the first disagreement on every kind of input,
+	// so that one bug isn't reported for every input.
+	Disagreements []*Disagreement
+}
+
+// Disagreement is an input on which the variants of a puzzle give different
+// answers to a part.
+type Disagreement struct {
+	Puzzles []day.Puzzle
+	Part    int
+	// Input says where the input came from, e.g. example.txt.
+	Input string
+	// Data is the input, or nil for the real input. Random inputs are
+	// shrunk.
+	Data []byte
+	// Got holds the answer or the error of each of the puzzles.
+	Got []string
+
+	failed []bool
+}
+
+func (d *Disagreement) Error() string {
+	var got []string
+	for i, p := range d.Puzzles {
+		got = append(got, fmt.Sprintf("%s: %s", p.Name(), d.Got[i]))
+	}
+
+	return fmt.Sprintf("part %d of %s: variants disagree, %s", d.Part, d.Input, strings.Join(got, ", "))
+}
+
+// Groups returns the puzzles that have more than one variant, a group per
+// day.
+func Groups(puzzles []day.Puzzle) [][]day.Puzzle {
+	var result [][]day.Puzzle
+
+	for _, p := range puzzles {
+		if slices.ContainsFunc(result, func(g []day.Puzzle) bool {
+			return g[0].Year == p.Year && g[0].Day == p.Day
+		}) {
+			continue
+		}
+
+		if variants := day.Variants(p.Year, p.Day); len(variants) > 1 {
+			result = append(result, variants)
+		}
+	}
+
+	return result
+}
+
+// Check compares the variants of a puzzle on all inputs. It returns an
+// error when an input can't be read, or when ctx is done.
+func (c Checker) Check(ctx context.Context, puzzles []day.Puzzle) (*Report, error) {
+	if len(puzzles) < 2 {
+		return nil, errors.New("nothing to compare, want at least two variants")
+	}
+
+	r := &Report{Puzzles: puzzles}
+
+	examples, err := c.examples(puzzles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range examples.names {
+		r.Examples++
+		if d := c.Compare(ctx, puzzles, name, examples.data[name]); d != nil {
+			r.Disagreements = append(r.Disagreements, d)
+			break
+		}
+	}
+
+	input, err := c.input(puzzles[0])
+	if err != nil {
+		return nil, err
+	}
+	if input != nil {
+		r.Input = true
+		if d := c.Compare(ctx, puzzles, "the input", input); d != nil {
+			// inputs are not to be shared, not even in logs
+			d.Data = nil
+			r.Disagreements = append(r.Disagreements, d)
+		}
+	}
+
+	if generate := puzzles[0].Generator(); generate != nil {
+		for run := range c.Runs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			size := 1 + run*MaxSize/c.Runs
+			data := generate(rand.New(rand.NewPCG(c.Seed, uint64(run))), size)
+			name := fmt.Sprintf("random input %d of seed %d", run, c.Seed)
+
+			r.Random++
+			if d := c.Compare(ctx, puzzles, name, data); d != nil {
+				r.Disagreements = append(r.Disagreements, c.Shrink(ctx, d))
+				break
+			}
+		}
+	}
+
+	return r, ctx.Err()
+}
+
+type examples struct {
+	names []string
+	data  map[string][]byte
+}
+
+// examples reads the examples of all puzzles. Variants mostly have copies of
+// the same examples, which are compared on once.
+func (c Checker) examples(puzzles []day.Puzzle) (examples, error) {
+	result := examples{data: make(map[string][]byte)}
+
+	for _, p := range puzzles {
+		fsys := p.Examples()
+		if fsys == nil {
+			continue
+		}
+
+		entries, err := fs.ReadDir(fsys, ".")
+		if err != nil {
+			return result, err
+		}
+
+		for _, e := range entries {
+			data, err := fs.ReadFile(fsys, e.Name())
+			if err != nil {
+				return result, err
+			}
+
+			name := fmt.Sprintf("%s %s", p.Name(), e.Name())
+			if !slices.ContainsFunc(result.names, func(n string) bool { return bytes.Equal(result.data[n], data) }) {
+				result.names = append(result.names, name)
+				result.data[name] = data
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// input reads the real input of the puzzle's day, or returns nil when there
+// is none.
+func (c Checker) input(p day.Puzzle) ([]byte, error) {
+	// without an inputs directory, there is no input
+	dir, err := day.InputsDir(c.InputsDir)
+	if err != nil {
+		return nil, nil
+	}
+
+	data, err := secrets.ReadFile(day.InputPath(dir, p.Year, p.Day))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+// outcome is what a puzzle gave for a part of an input.
+type outcome struct {
+	got    string
+	failed bool
+}
+
+func (c Checker) solve(ctx context.Context, p day.Puzzle, part int, data []byte) outcome {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, c.Timeout, fmt.Errorf("%w after %s", day.ErrTimeout, c.Timeout))
+		defer cancel()
+	}
+
+	// inputs are parsed for every part rather than cached, as random
+	// inputs would only fill the cache
+	a, err := p.Part(ctx, part, day.WithReader(bytes.NewReader(data)), day.WithoutCache())
+	if err != nil && ctx.Err() != nil {
+		err = context.Cause(ctx)
+	}
+	if err != nil {
+		return outcome{"error: " + err.Error(), true}
+	}
+
+	return outcome{a.String(), false}
+}
+
+// Compare solves both parts of an input, named name, with all puzzles, and
+// returns the first part they disagree on, or nil when they agree. Puzzles
+// agree when they give the same answer, or when they all fail; their
+// errors may differ.
+func (c Checker) Compare(ctx context.Context, puzzles []day.Puzzle, name string, data []byte) *Disagreement {
+	for part := 1; part <= 2; part++ {
+		if d := c.compare(ctx, puzzles, part, name, data); d != nil {
+			return d
+		}
+	}
+
+	return nil
+}
+
+func (c Checker) compare(ctx context.Context, puzzles []day.Puzzle, part int, name string, data []byte) *Disagreement {
+	d := &Disagreement{Puzzles: puzzles, Part: part, Input: name, Data: data}
+	agree := true
+
+	for i, p := range puzzles {
+		o := c.solve(ctx, p, part, data)
+		d.Got = append(d.Got, o.got)
+		d.failed = append(d.failed, o.failed)
+
+		if i > 0 && (o.failed != d.failed[0] || !o.failed && o.got != d.Got[0]) {
+			agree = false
+		}
+	}
+
+	if agree {
+		return nil
+	}
+
+	return d
+}
+
+// Shrink returns a disagreement on the smallest input it finds that shows
+// the same disagreement as d: on the same part, with the same puzzles
+// failing. It removes ever smaller runs of lines, and then of bytes, as long
+// as the puzzles keep disagreeing.
+func (c Checker) Shrink(ctx context.Context, d *Disagreement) *Disagreement {
+	best := d
+
+	// the same disagreement, rather than for example one variant failing
+	// to parse an input that lost half a line
+	same := func(data []byte) *Disagreement {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		e := c.compare(ctx, d.Puzzles, d.Part, d.Input, data)
+		if e == nil || !slices.Equal(e.failed, d.failed) {
+			return nil
+		}
+
+		return e
+	}
+
+	for _, sep := range []string{"\n", ""} {
+		units := strings.SplitAfter(string(best.Data), sep)
+
+		for n := len(units) / 2; n >= 1; n /= 2 {
+			for i := 0; i+n <= len(units); {
+				candidate := slices.Concat(units[:i], units[i+n:])
+
+				if e := same([]byte(strings.Join(candidate, ""))); e != nil {
+					best, units = e, candidate
+				} else {
+					i += n
+				}
+			}
+		}
+	}
+
+	best.Input = fmt.Sprintf("%s, shrunk from %d to %d bytes", d.Input, len(d.Data), len(best.Data))
+	return best
+}
@@ -0,0 +1,154 @@
+package difftest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"adventofcode/internal/day"
+)
+
+var exampleFS = fstest.MapFS{
+	"example.txt": {Data: []byte("1\n2\n3\n")},
+}
+
+// sum adds up the number of digits and the last digit of the numbers of its
+// input for part 1, and counts them for part 2. Its buggy variant skips
+// numbers with a 7 in part 1.
+type sum struct {
+	numbers []string
+	buggy   bool
+}
+
+func newSum(buggy bool) func(...day.Option) (sum, error) {
+	return func(opts ...day.Option) (sum, error) {
+		input, err := day.NewDayInput(2015, 8, exampleFS, opts...)
+		if err != nil {
+			return sum{}, err
+		}
+
+		numbers, err := day.Load(input.Expect(day.Shape{{Name: "numbers", Pattern: `\d+`}}), func(d day.DayInput) ([]string, error) {
+			return d.ReadLines()
+		})
+
+		return sum{numbers, buggy}, err
+	}
+}
+
+func (s sum) Part1() (day.Answer, error) {
+	total := 0
+	for _, n := range s.numbers {
+		if !s.buggy || !strings.Contains(n, "7") {
+			total += len(n) + int(n[len(n)-1]-'0')
+		}
+	}
+
+	return day.Int(total), nil
+}
+
+func (s sum) Part2() (day.Answer, error) {
+	return day.Int(len(s.numbers)), nil
+}
+
+// generate returns lines of numbers, with a 7 in one line out of fifty.
+func generate(r *rand.Rand, size int) []byte {
+	var b strings.Builder
+	for range 10 * size {
+		n := r.IntN(7)
+		if r.IntN(50) == 0 {
+			n = 700 + r.IntN(100)
+		}
+		fmt.Fprintln(&b, n)
+	}
+
+	return []byte(b.String())
+}
+
+func init() {
+	day.Register(2015, 8, "", newSum(false))
+	day.Register(2015, 8, "b", newSum(false))
+	day.Register(2015, 9, "", newSum(false))
+	day.Register(2015, 9, "b", newSum(true))
+	day.Register(2015, 10, "", newSum(false))
+	day.RegisterGenerator(2015, 8, generate)
+	day.RegisterGenerator(2015, 9, generate)
+}
+
+func checker(t *testing.T) Checker {
+	return Checker{Runs: 50, Seed: 1, Timeout: time.Second, InputsDir: t.TempDir()}
+}
+
+func TestGroups(t *testing.T) {
+	var got []string
+	for _, g := range Groups(day.Puzzles()) {
+		var names []string
+		for _, p := range g {
+			names = append(names, p.Name())
+		}
+		got = append(got, strings.Join(names, " "))
+	}
+
+	if want := "2015/08 2015/08b,2015/09 2015/09b"; strings.Join(got, ",") != want {
+		t.Errorf("want groups %s, got %s", want, strings.Join(got, ","))
+	}
+}
+
+func TestAgree(t *testing.T) {
+	r, err := checker(t).Check(t.Context(), day.Variants(2015, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// both variants embed the same example
+	if r.Examples != 1 || r.Input || r.Random != 50 {
+		t.Errorf("want 1 example, no input and 50 random inputs, got %d, %t and %d", r.Examples, r.Input, r.Random)
+	}
+	for _, d := range r.Disagreements {
+		t.Error(d)
+	}
+}
+
+func TestDisagree(t *testing.T) {
+	r, err := checker(t).Check(t.Context(), day.Variants(2015, 9))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.Disagreements) != 1 {
+		t.Fatalf("want a disagreement on a random input, got %v", r.Disagreements)
+	}
+
+	d := r.Disagreements[0]
+	if d.Part != 1 || !strings.Contains(d.Input, "random input") {
+		t.Errorf("want part 1 of a random input, got %v", d)
+	}
+
+	// a single line with a 7 shows the bug
+	lines := strings.Fields(string(d.Data))
+	if len(lines) != 1 || !strings.Contains(lines[0], "7") {
+		t.Errorf("want the input shrunk to one number with a 7, got %q", d.Data)
+	}
+
+	n, _ := strconv.Atoi(lines[0])
+	if want := fmt.Sprintf("%d", len(lines[0])+n%10); d.Got[0] != want || d.Got[1] != "0" {
+		t.Errorf("want answers %s and 0, got %v", want, d.Got)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	c := checker(t)
+	puzzles := day.Variants(2015, 9)
+
+	// all variants failing to parse an input is agreement
+	if d := c.Compare(t.Context(), puzzles, "bad", []byte("x\n")); d != nil {
+		t.Errorf("want agreement on a bad input, got %v", d)
+	}
+
+	if _, err := c.Check(t.Context(), day.Variants(2015, 10)); err == nil {
+		t.Error("want an error for a single variant")
+	}
+}
@@ -0,0 +1,241 @@
+// Package fetch downloads puzzle inputs from the Advent of Code website.
+//
+// Inputs are personal, so every request carries the session cookie of a
+// logged in user. Downloaded inputs are cached on disk, and requests are
+// spaced out, so that the site is never asked twice for the same input.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/secrets"
+)
+
+const (
+	DefaultBaseURL   = "https://adventofcode.com"
+	DefaultUserAgent = "github.com/gerardkok/adventofcode (aoc fetch)"
+
+	// DefaultInterval is the minimum time between two requests.
+	DefaultInterval = 3 * time.Second
+
+	// LastRequestFile is the file in the inputs directory that holds the
+	// time of the last request, so that requests are spaced out across
+	// runs of aoc too.
+	LastRequestFile = ".last-request"
+)
+
+// ErrNoSession is returned when no session cookie is configured.
+var ErrNoSession = errors.New("no session, set AOC_SESSION or write it to the session file")
+
+type Client struct {
+	BaseURL   string
+	Session   string
+	UserAgent string
+
+	// Dir is the inputs directory, where inputs are cached with the
+	// same layout that the days read them from.
+	Dir string
+
+	// Interval is the minimum time between two requests.
+	Interval time.Duration
+
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	sleep func(context.Context, time.Duration) error
+}
+
+// New returns a client with the default settings that caches inputs in dir.
+func New(dir, session string) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		Session:    session,
+		UserAgent:  DefaultUserAgent,
+		Dir:        dir,
+		Interval:   DefaultInterval,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// Path returns where the input of a day is cached.
+func (c *Client) Path(year, number int) string {
+	return day.InputPath(c.Dir, year, number)
+}
+
+// Input returns the input for a day, from the cache if it is there, also
+// when it is encrypted, and downloads it otherwise.
+func (c *Client) Input(ctx context.Context, year, number int) ([]byte, error) {
+	path := c.Path(year, number)
+
+	if data, err := secrets.ReadFile(path); err == nil {
+		return data, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	data, err := c.download(ctx, year, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	// inputs are not to be shared, and are encrypted once there is a key
+	if err := secrets.Save(path, data, 0o644); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (c *Client) download(ctx context.Context, year, number int) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(c.BaseURL, "/"), year, number)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: day %d of %d is not available (yet)", url, number, year)
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		// the site answers with one of these when the session is invalid
+		return nil, fmt.Errorf("%s: %s, is the session still valid?", url, resp.Status)
+	default:
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+}
+
+// Do sends a request, waiting first if the previous request was sent less
+// than Interval ago, by this client or by any other with the same Dir.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if err := c.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
+}
+
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := filepath.Join(c.Dir, LastRequestFile)
+
+	if last, err := readTime(path); err == nil {
+		if d := c.Interval - time.Since(last); d > 0 {
+			sleep := c.sleep
+			if sleep == nil {
+				sleep = sleepContext
+			}
+			if err := sleep(ctx, d); err != nil {
+				return err
+			}
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, time.Now().AppendFormat(nil, time.RFC3339Nano), 0o644)
+}
+
+// readTime reads the time of the last request from path.
+func readTime(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return t, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Session returns the session cookie from the AOC_SESSION environment
+// variable, or otherwise from the session file.
+func Session() (string, error) {
+	if s := os.Getenv("AOC_SESSION"); s != "" {
+		return s, nil
+	}
+
+	path, err := SessionFile()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	} else if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SessionFile returns the path of the file that holds the session cookie:
+// adventofcode/session in the user's config directory.
+func SessionFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "adventofcode", "session"), nil
+}
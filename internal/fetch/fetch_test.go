@@ -0,0 +1,200 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"adventofcode/internal/secrets"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	// inputs are cached in plain text, unless a test sets a key
+	t.Setenv("AOC_KEY", "")
+	t.Setenv("AOC_KEY_FILE", filepath.Join(t.TempDir(), "key"))
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := New(t.TempDir(), "secret")
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.Interval = 0
+
+	return c
+}
+
+func TestInput(t *testing.T) {
+	requests := 0
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/2024/day/7/input" {
+			t.Errorf("want path /2024/day/7/input, got %s", r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			t.Errorf("want session cookie secret, got %v", cookie)
+		}
+		if ua := r.UserAgent(); ua != DefaultUserAgent {
+			t.Errorf("want user agent %q, got %q", DefaultUserAgent, ua)
+		}
+
+		w.Write([]byte("190: 10 19\n"))
+	})
+
+	for range 2 {
+		got, err := c.Input(context.Background(), 2024, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "190: 10 19\n" {
+			t.Errorf("want input %q, got %q", "190: 10 19\n", got)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("want 1 request, got %d", requests)
+	}
+
+	cached, err := os.ReadFile(c.Path(2024, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(cached) != "190: 10 19\n" {
+		t.Errorf("want cached input %q, got %q", "190: 10 19\n", cached)
+	}
+}
+
+func TestInputEncrypted(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("190: 10 19\n"))
+	})
+
+	k, _ := secrets.NewKey()
+	t.Setenv("AOC_KEY", k.String())
+
+	if _, err := c.Input(context.Background(), 2024, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(c.Path(2024, 7)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want no plain input with a key, got %v", err)
+	}
+
+	cached, err := secrets.ReadFile(c.Path(2024, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(cached) != "190: 10 19\n" {
+		t.Errorf("want cached input %q, got %q", "190: 10 19\n", cached)
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+	})
+
+	if _, err := c.Input(context.Background(), 2024, 1); err == nil {
+		t.Fatal("want error for bad session, got nil")
+	}
+	if _, err := os.Stat(c.Path(2024, 1)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want no cached input after an error, got %v", err)
+	}
+
+	c.Session = ""
+	if _, err := c.Input(context.Background(), 2024, 1); !errors.Is(err, ErrNoSession) {
+		t.Errorf("want ErrNoSession, got %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("input\n"))
+	})
+	c.Interval = time.Minute
+
+	var waited []time.Duration
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		waited = append(waited, d)
+		return nil
+	}
+
+	for _, number := range []int{1, 2} {
+		if _, err := c.Input(context.Background(), 2024, number); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(waited) != 1 || waited[0] <= 0 || waited[0] > time.Minute {
+		t.Errorf("want a single wait of at most a minute, got %v", waited)
+	}
+}
+
+func TestRateLimitOnDisk(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("input\n"))
+	})
+	c.Interval = time.Minute
+
+	if _, err := c.Input(context.Background(), 2024, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// another client on the same inputs directory, as in a later run of
+	// aoc, waits as well
+	other := New(c.Dir, c.Session)
+	other.BaseURL, other.HTTPClient, other.Interval = c.BaseURL, c.HTTPClient, c.Interval
+
+	var waited []time.Duration
+	other.sleep = func(_ context.Context, d time.Duration) error {
+		waited = append(waited, d)
+		return nil
+	}
+
+	if _, err := other.Input(context.Background(), 2024, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(waited) != 1 || waited[0] <= 0 || waited[0] > time.Minute {
+		t.Errorf("want a single wait of at most a minute, got %v", waited)
+	}
+}
+
+func TestSession(t *testing.T) {
+	t.Setenv("AOC_SESSION", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := Session(); !errors.Is(err, ErrNoSession) {
+		t.Errorf("want ErrNoSession, got %v", err)
+	}
+
+	path, err := SessionFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := Session(); err != nil || got != "from-file" {
+		t.Errorf("want session from-file, got %q, %v", got, err)
+	}
+
+	t.Setenv("AOC_SESSION", "from-env")
+	if got, err := Session(); err != nil || got != "from-env" {
+		t.Errorf("want session from-env, got %q, %v", got, err)
+	}
+}
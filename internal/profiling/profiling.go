@@ -0,0 +1,166 @@
+// Package profiling writes profiles of a run of the solutions: CPU and
+// memory profiles and execution traces for looking into a slow day, and CPU
+// profiles collected over many runs for profile-guided optimisation.
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+)
+
+// Profiler starts and stops the profiles that are asked for by its fields,
+// usually set from command line flags with AddFlags.
+type Profiler struct {
+	CPUProfile string
+	MemProfile string
+	Trace      string
+	// Collect keeps the CPU profile in Dir too, for merging into a profile
+	// for profile-guided optimisation later. It is kept as Name.pprof, so
+	// that a later run of the same days replaces it rather than adding
+	// another; the default name is cpu.
+	Collect bool
+	Dir     string
+	Name    string
+
+	cpu   *bytes.Buffer
+	trace *os.File
+}
+
+// AddFlags defines the profiling flags on fset.
+func (p *Profiler) AddFlags(fset *flag.FlagSet) {
+	fset.StringVar(&p.CPUProfile, "cpuprofile", "", "write a CPU profile to `file`")
+	fset.StringVar(&p.MemProfile, "memprofile", "", "write an allocation profile to `file`")
+	fset.StringVar(&p.Trace, "trace", "", "write an execution trace to `file`")
+	fset.BoolVar(&p.Collect, "pgo-collect", false, "keep the CPU profile for aoc pgo")
+}
+
+// Start starts profiling. Stop must be called to write the profiles.
+func (p *Profiler) Start() error {
+	if p.CPUProfile != "" || p.Collect {
+		// the profile may be written twice, so it is kept in memory
+		p.cpu = new(bytes.Buffer)
+		if err := pprof.StartCPUProfile(p.cpu); err != nil {
+			return err
+		}
+	}
+
+	if p.Trace != "" {
+		file, err := os.Create(p.Trace)
+		if err != nil {
+			p.Stop()
+			return err
+		}
+
+		if err := trace.Start(file); err != nil {
+			file.Close()
+			p.Stop()
+			return err
+		}
+		p.trace = file
+	}
+
+	return nil
+}
+
+// Stop stops profiling and writes the profiles.
+func (p *Profiler) Stop() error {
+	var errs []error
+
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+
+		if p.CPUProfile != "" {
+			errs = append(errs, os.WriteFile(p.CPUProfile, p.cpu.Bytes(), 0o644))
+		}
+		if p.Collect {
+			_, err := p.keep(p.cpu.Bytes())
+			errs = append(errs, err)
+		}
+		p.cpu = nil
+	}
+
+	if p.trace != nil {
+		trace.Stop()
+		errs = append(errs, p.trace.Close())
+		p.trace = nil
+	}
+
+	if p.MemProfile != "" {
+		errs = append(errs, writeMemProfile(p.MemProfile))
+	}
+
+	return errors.Join(errs...)
+}
+
+// keep writes a collected profile to Dir, replacing an older profile of
+// the same name.
+func (p *Profiler) keep(data []byte) (string, error) {
+	if p.Dir == "" {
+		return "", errors.New("no directory for collected profiles")
+	}
+
+	if err := os.MkdirAll(p.Dir, 0o755); err != nil {
+		return "", err
+	}
+
+	name := p.Name
+	if name == "" {
+		name = "cpu"
+	}
+	path := filepath.Join(p.Dir, name+".pprof")
+
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+func writeMemProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	// get up to date statistics
+	runtime.GC()
+
+	if err := pprof.Lookup("allocs").WriteTo(file, 0); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Collected returns the profiles collected in dir.
+func Collected(dir string) ([]string, error) {
+	return filepath.Glob(filepath.Join(dir, "*.pprof"))
+}
+
+// Merge merges the CPU profiles in files into one, and writes it to output
+// in the format that go build reads for profile-guided optimisation. It
+// runs go tool pprof -proto, which merges profiles the same way by hand:
+//
+//	go tool pprof -proto a.pprof b.pprof > default.pgo
+func Merge(ctx context.Context, output string, files ...string) error {
+	if len(files) == 0 {
+		return errors.New("no profiles to merge")
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "go", append([]string{"tool", "pprof", "-proto"}, files...)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go tool pprof: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return os.WriteFile(output, stdout.Bytes(), 0o644)
+}
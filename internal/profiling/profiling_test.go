@@ -0,0 +1,84 @@
+package profiling
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func work() int {
+	sum := 0
+	for i := range 1_000_000 {
+		sum += i % 7
+	}
+	return sum
+}
+
+func TestProfiler(t *testing.T) {
+	dir := t.TempDir()
+
+	p := Profiler{
+		CPUProfile: filepath.Join(dir, "cpu.out"),
+		MemProfile: filepath.Join(dir, "mem.out"),
+		Trace:      filepath.Join(dir, "trace.out"),
+		Collect:    true,
+		Dir:        filepath.Join(dir, "pgo"),
+	}
+
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	work()
+	if err := p.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{p.CPUProfile, p.MemProfile, p.Trace} {
+		if info, err := os.Stat(name); err != nil || info.Size() == 0 {
+			t.Errorf("want %s written, got %v", filepath.Base(name), err)
+		}
+	}
+
+	// a profile of the same days replaces the one before, one of other
+	// days is kept next to it
+	for _, name := range []string{"", "2024-16"} {
+		p.Name = name
+		if err := p.Start(); err != nil {
+			t.Fatal(err)
+		}
+		work()
+		if err := p.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	collected, err := Collected(p.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collected) != 2 {
+		t.Fatalf("want 2 collected profiles, got %v", collected)
+	}
+
+	output := filepath.Join(dir, "default.pgo")
+	if err := Merge(t.Context(), output, collected...); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(output); err != nil || info.Size() == 0 {
+		t.Errorf("want the merged profile written, got %v", err)
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "default.pgo")
+
+	if err := Merge(t.Context(), output); err == nil {
+		t.Error("want an error without profiles")
+	}
+	if err := Merge(t.Context(), output, filepath.Join(t.TempDir(), "missing.pprof")); err == nil {
+		t.Error("want an error for a missing profile")
+	}
+	if _, err := os.Stat(output); err == nil {
+		t.Error("want no merged profile after an error")
+	}
+}
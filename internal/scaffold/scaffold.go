@@ -0,0 +1,191 @@
+// Package scaffold creates the package for a new day from templates: the
+// solution, its tests, an empty example with a line per part for its
+// expected answers, and the import that links it into the registry. The
+// answers are left out, so that the tests fail until they are filled in.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+var (
+	tmpl      = template.Must(template.ParseFS(templates, "templates/*.tmpl"))
+	variantRE = regexp.MustCompile(`^[a-z]*$`)
+)
+
+const (
+	module  = "adventofcode"
+	daysDir = "internal/days"
+)
+
+// Day describes the package of a day, and is what the templates are
+// executed with.
+type Day struct {
+	Year    int
+	Number  int
+	Variant string
+}
+
+func (d Day) Package() string {
+	return fmt.Sprintf("day%02d%s", d.Number, d.Variant)
+}
+
+func (d Day) Constructor() string {
+	return fmt.Sprintf("NewDay%02d%s", d.Number, d.Variant)
+}
+
+func (d Day) Parts() []int {
+	return []int{1, 2}
+}
+
+// Generate creates the package for a day of a year in the module at root,
+// in internal/days/<year>, and returns the files it created or changed. It
+// refuses to touch a day that already has a package or is already
+// registered, and to create a variant of a day that isn't registered.
+func Generate(root string, year, number int, variant string) ([]string, error) {
+	d := Day{year, number, variant}
+
+	if year < 2015 {
+		return nil, fmt.Errorf("there was no event in %d", year)
+	}
+	if number < 1 || number > 25 {
+		return nil, fmt.Errorf("day %d is not in the calendar", number)
+	}
+	if !variantRE.MatchString(variant) {
+		return nil, fmt.Errorf("variant %q is not lower case letters", variant)
+	}
+
+	dir := filepath.Join(root, filepath.FromSlash(daysDir), fmt.Sprint(year), d.Package())
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", dir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	registry := filepath.Join(root, filepath.FromSlash(daysDir), "days.go")
+	imports, err := addImport(registry, d)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		filepath.Join(dir, "example.txt"): nil,
+	}
+
+	for name, t := range map[string]string{
+		d.Package() + ".go":      "day.go.tmpl",
+		d.Package() + "_test.go": "day_test.go.tmpl",
+		"expected.txt":           "expected.txt.tmpl",
+	} {
+		var b bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&b, t, d); err != nil {
+			return nil, err
+		}
+
+		if filepath.Ext(name) != ".go" {
+			files[filepath.Join(dir, name)] = b.Bytes()
+			continue
+		}
+
+		src, err := format.Source(b.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+
+		files[filepath.Join(dir, name)] = src
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	created := slices.Sorted(maps.Keys(files))
+
+	for _, name := range created {
+		if err := writeNew(name, files[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.WriteFile(registry, imports, 0o644); err != nil {
+		return nil, err
+	}
+
+	return append(created, registry), nil
+}
+
+// importLine returns the line of the registry file that imports d.
+func importLine(d Day) string {
+	return fmt.Sprintf("\t_ \"%s/%s/%d/%s\"", module, daysDir, d.Year, d.Package())
+}
+
+// addImport returns the registry file with a blank import of the day
+// added to it, keeping the imports sorted. A variant is only added next to
+// the day it is a variant of.
+func addImport(registry string, d Day) ([]byte, error) {
+	data, err := os.ReadFile(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	line := importLine(d)
+
+	lines := strings.Split(string(data), "\n")
+	start := slices.Index(lines, "import (")
+	if start == -1 {
+		return nil, fmt.Errorf("%s: no import block", registry)
+	}
+
+	end := start + 1
+	for end < len(lines) && lines[end] != ")" {
+		if lines[end] == line {
+			return nil, fmt.Errorf("%s already imports %s", registry, d.Package())
+		}
+		end++
+	}
+
+	if d.Variant != "" {
+		base := Day{d.Year, d.Number, ""}
+		if !slices.Contains(lines[start+1:end], importLine(base)) {
+			return nil, fmt.Errorf("no day %d/%02d to create variant %s of, create the day first", d.Year, d.Number, d.Variant)
+		}
+	}
+
+	i := start + 1
+	for i < end && lines[i] < line {
+		i++
+	}
+
+	lines = slices.Insert(lines, i, line)
+
+	return format.Source([]byte(strings.Join(lines, "\n")))
+}
+
+// writeNew writes a file that must not exist yet.
+func writeNew(name string, data []byte) error {
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,123 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const registry = `// Package days links every solution into the day registry.
+package days
+
+import (
+	_ "adventofcode/internal/days/2024/day01"
+	_ "adventofcode/internal/days/2024/day07"
+	_ "adventofcode/internal/days/2024/day08"
+)
+`
+
+func newRoot(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "internal", "days")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "days.go"), []byte(registry), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return root
+}
+
+func TestGenerate(t *testing.T) {
+	root := newRoot(t)
+
+	files, err := Generate(root, 2024, 7, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 5 {
+		t.Errorf("want 5 files, got %v", files)
+	}
+
+	src, err := os.ReadFile(filepath.Join(root, "internal", "days", "2024", "day07b", "day07b.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"package day07b", "func NewDay07b(", `day.Register(2024, 7, "b", NewDay07b)`, "day.NewDayInput(2024, 7, examples, opts...)"} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("want %q in day07b.go", want)
+		}
+	}
+
+	// without answers, the tests of a new day fail until they are added
+	expected, err := os.ReadFile(filepath.Join(root, "internal", "days", "2024", "day07b", "expected.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "example.txt  1\nexample.txt  2\n"; !strings.HasSuffix(string(expected), want) {
+		t.Errorf("want examples without answers in expected.txt, got\n%s", expected)
+	}
+
+	days, err := os.ReadFile(filepath.Join(root, "internal", "days", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "day07\"\n\t_ \"adventofcode/internal/days/2024/day07b\"\n\t_ \"adventofcode/internal/days/2024/day08\""
+	if !strings.Contains(string(days), want) {
+		t.Errorf("want day07b imported between day07 and day08, got\n%s", days)
+	}
+}
+
+func TestGenerateRefusesToOverwrite(t *testing.T) {
+	root := newRoot(t)
+
+	if _, err := Generate(root, 2024, 1, ""); err == nil {
+		t.Error("want error for a registered day, got nil")
+	}
+
+	dir := filepath.Join(root, "internal", "days", "2024", "day02")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Generate(root, 2024, 2, ""); err == nil {
+		t.Error("want error for an existing package, got nil")
+	}
+
+	for _, tc := range []struct {
+		year, number int
+		variant      string
+	}{{2024, 0, ""}, {2024, 26, ""}, {2024, 3, "B"}, {2024, 3, "../x"}, {1999, 3, ""}, {2024, 5, "c"}, {2025, 1, "b"}} {
+		if _, err := Generate(root, tc.year, tc.number, tc.variant); err == nil {
+			t.Errorf("day %d/%d%s: want error, got nil", tc.year, tc.number, tc.variant)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "internal", "days", "2024", "day05c")); err == nil {
+		t.Error("want no package for a variant of a missing day")
+	}
+}
+
+func TestGenerateNewYear(t *testing.T) {
+	root := newRoot(t)
+
+	if _, err := Generate(root, 2025, 1, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "internal", "days", "2025", "day01", "day01.go")); err != nil {
+		t.Error(err)
+	}
+
+	days, err := os.ReadFile(filepath.Join(root, "internal", "days", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "2024/day08\"\n\t_ \"adventofcode/internal/days/2025/day01\"\n)"
+	if !strings.Contains(string(days), want) {
+		t.Errorf("want 2025/day01 imported last, got\n%s", days)
+	}
+}
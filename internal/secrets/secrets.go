@@ -0,0 +1,379 @@
+// Package secrets keeps puzzle inputs and answers encrypted at rest, so
+// that they can't be published by accident. The puzzle authors ask that
+// inputs are not shared.
+//
+// An encrypted file has the name of the plain file with .enc added, e.g.
+// day07.txt.enc, and is encrypted with AES-256-GCM under a local key. The
+// key is read from the AOC_KEY environment variable, as 64 hex digits, or
+// otherwise from the key file. ReadFile reads either kind of file, so that
+// callers don't need to know whether a file is encrypted.
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Ext is the extension of encrypted files.
+const Ext = ".enc"
+
+// magic starts every encrypted file, for telling them apart from files
+// that merely have the extension, and for changing the format later.
+const magic = "aoc-enc-v1\n"
+
+var (
+	// ErrNoKey is returned when neither AOC_KEY nor the key file is set.
+	ErrNoKey = errors.New("no key, set AOC_KEY or create a key file with aoc encrypt")
+	// ErrDecrypt is returned for files that were encrypted with another
+	// key, or that were damaged.
+	ErrDecrypt = errors.New("cannot decrypt, wrong key or damaged file")
+)
+
+// Key is an AES-256 key.
+type Key [32]byte
+
+// NewKey returns a random key.
+func NewKey() (Key, error) {
+	var k Key
+	_, err := rand.Read(k[:])
+	return k, err
+}
+
+// ParseKey parses a key written as 64 hex digits.
+func ParseKey(s string) (Key, error) {
+	var k Key
+
+	b, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(b) != len(k) {
+		return k, fmt.Errorf("bad key, want %d hex digits", 2*len(k))
+	}
+
+	copy(k[:], b)
+	return k, nil
+}
+
+func (k Key) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// KeyFile returns the path of the file that holds the key: AOC_KEY_FILE,
+// or adventofcode/key in the user's config directory.
+func KeyFile() (string, error) {
+	if path := os.Getenv("AOC_KEY_FILE"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "adventofcode", "key"), nil
+}
+
+// LoadKey returns the key from the AOC_KEY environment variable, or
+// otherwise from the key file.
+func LoadKey() (Key, error) {
+	if s := os.Getenv("AOC_KEY"); s != "" {
+		k, err := ParseKey(s)
+		if err != nil {
+			return k, fmt.Errorf("AOC_KEY: %w", err)
+		}
+		return k, nil
+	}
+
+	path, err := KeyFile()
+	if err != nil {
+		return Key{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Key{}, ErrNoKey
+	} else if err != nil {
+		return Key{}, err
+	}
+
+	k, err := ParseKey(string(data))
+	if err != nil {
+		return k, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return k, nil
+}
+
+// SaveKey writes k to a new key file, readable by the user only. It never
+// overwrites a key, as that would make the files encrypted with it
+// unreadable.
+func SaveKey(k Key) (string, error) {
+	path, err := KeyFile()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := fmt.Fprintln(file, k); err != nil {
+		file.Close()
+		return "", err
+	}
+
+	return path, file.Close()
+}
+
+func newGCM(k Key) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts data with k, under a random nonce.
+func Encrypt(k Key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(k)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(magic)+gcm.NonceSize(), len(magic)+gcm.NonceSize()+len(data)+gcm.Overhead())
+	copy(out, magic)
+
+	nonce := out[len(magic):]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(out, nonce, data, []byte(magic)), nil
+}
+
+// Decrypt decrypts data that was encrypted with k.
+func Decrypt(k Key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(k)
+	if err != nil {
+		return nil, err
+	}
+
+	rest, ok := bytes.CutPrefix(data, []byte(magic))
+	if !ok {
+		return nil, errors.New("not an encrypted file")
+	}
+	if len(rest) < gcm.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	nonce, sealed := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+
+	plain, err := gcm.Open(nil, nonce, sealed, []byte(magic))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return plain, nil
+}
+
+// Encrypted reports whether name is the name of an encrypted file.
+func Encrypted(name string) bool {
+	return strings.HasSuffix(name, Ext)
+}
+
+// Find returns the file to read for name: the encrypted name.enc if there
+// is one, and name otherwise. When both exist, as after decrypting a file
+// to edit it, it returns the one changed last, so that edits aren't
+// ignored. The error is that of name when neither exists.
+func Find(name string) (string, error) {
+	info, err := os.Stat(name)
+
+	if !Encrypted(name) {
+		if enc, encErr := os.Stat(name + Ext); encErr == nil && (err != nil || !info.ModTime().After(enc.ModTime())) {
+			return name + Ext, nil
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// ReadFile reads the file for name that Find returns, decrypting it if it
+// is encrypted.
+func ReadFile(name string) ([]byte, error) {
+	path, err := Find(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || !Encrypted(path) {
+		return data, err
+	}
+
+	k, err := LoadKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	plain, err := Decrypt(k, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return plain, nil
+}
+
+// WriteFile writes data to name, encrypted if name is the name of an
+// encrypted file. It writes to a temporary file first, so that an
+// interrupted write never leaves a truncated file behind.
+func WriteFile(name string, data []byte, perm os.FileMode) error {
+	if Encrypted(name) {
+		k, err := LoadKey()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if data, err = Encrypt(k, data); err != nil {
+			return err
+		}
+	}
+
+	return writeAtomic(name, data, perm)
+}
+
+// writeAtomic writes data to a temporary file next to name, and renames it
+// to name once it is complete.
+func writeAtomic(name string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// Save writes data to the file for name, which is not the name of an
+// encrypted file. It is encrypted into name.enc when there is a key, or
+// when name.enc exists already, and written to name when there is neither.
+// A plain name left from before there was a key is removed once data is
+// encrypted.
+func Save(name string, data []byte, perm os.FileMode) error {
+	_, err := LoadKey()
+	if err != nil && !errors.Is(err, ErrNoKey) {
+		return err
+	}
+
+	encrypt := err == nil
+	if _, err := os.Stat(name + Ext); err == nil {
+		encrypt = true
+	}
+
+	if !encrypt {
+		return WriteFile(name, data, perm)
+	}
+
+	if err := WriteFile(name+Ext, data, perm); err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// EncryptFile encrypts the file name into name.enc, replacing an older
+// encrypted copy, and returns the name of the encrypted file. The plain
+// file is removed unless keep is set.
+func EncryptFile(k Key, name string, keep bool) (string, error) {
+	if Encrypted(name) {
+		return "", fmt.Errorf("%s is encrypted already", name)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := Encrypt(k, data)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeAtomic(name+Ext, sealed, info.Mode().Perm()); err != nil {
+		return "", err
+	}
+
+	if keep {
+		return name + Ext, nil
+	}
+
+	return name + Ext, os.Remove(name)
+}
+
+// DecryptFile decrypts the file name, which ends in .enc, into a plain file
+// without the extension, and returns its name. The encrypted file is kept,
+// and an existing plain file is never overwritten.
+func DecryptFile(k Key, name string) (string, error) {
+	plainName, ok := strings.CutSuffix(name, Ext)
+	if !ok {
+		return "", fmt.Errorf("%s is not encrypted, want a name ending in %s", name, Ext)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := Decrypt(k, data)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	file, err := os.OpenFile(plainName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := file.Write(plain); err != nil {
+		file.Close()
+		return "", err
+	}
+
+	return plainName, file.Close()
+}
@@ -0,0 +1,178 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testKey(t *testing.T) Key {
+	t.Helper()
+
+	k, err := NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("AOC_KEY", k.String())
+	return k
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	k := testKey(t)
+	plain := []byte("3   4\n4   3\n")
+
+	sealed, err := Encrypt(k, plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, plain) {
+		t.Error("encrypted data contains the plain text")
+	}
+
+	got, err := Decrypt(k, sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Errorf("want %q, got %q", plain, got)
+	}
+
+	other, _ := NewKey()
+	if _, err := Decrypt(other, sealed); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("other key: want %v, got %v", ErrDecrypt, err)
+	}
+
+	sealed[len(sealed)-1] ^= 1
+	if _, err := Decrypt(k, sealed); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("damaged file: want %v, got %v", ErrDecrypt, err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	k, _ := NewKey()
+
+	got, err := ParseKey(k.String() + "\n")
+	if err != nil || got != k {
+		t.Errorf("want %s, got %s, %v", k, got, err)
+	}
+
+	for _, s := range []string{"", "abc", k.String()[2:], "zz" + k.String()[2:]} {
+		if _, err := ParseKey(s); err == nil {
+			t.Errorf("%q: want an error", s)
+		}
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	t.Setenv("AOC_KEY", "")
+	t.Setenv("AOC_KEY_FILE", filepath.Join(t.TempDir(), "key"))
+
+	if _, err := LoadKey(); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("want %v, got %v", ErrNoKey, err)
+	}
+
+	k, _ := NewKey()
+	path, err := SaveKey(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := LoadKey(); err != nil || got != k {
+		t.Errorf("want %s, got %s, %v", k, got, err)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("want a key file readable by the user only, got %v, %v", info.Mode(), err)
+	}
+
+	if _, err := SaveKey(k); err == nil {
+		t.Error("SaveKey overwrote the key")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	k := testKey(t)
+	name := filepath.Join(t.TempDir(), "day01.txt")
+	plain := []byte("1 2\n")
+
+	if err := os.WriteFile(name, plain, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := EncryptFile(k, name, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encrypted != name+Ext {
+		t.Errorf("want %s, got %s", name+Ext, encrypted)
+	}
+	if _, err := os.Stat(name); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("plain file not removed: %v", err)
+	}
+
+	// the plain name reads the encrypted file
+	if got, err := ReadFile(name); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("want %q, got %q, %v", plain, got, err)
+	}
+
+	if err := WriteFile(encrypted, []byte("3 4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, err := DecryptFile(k, encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(decrypted); string(got) != "3 4\n" {
+		t.Errorf("want %q, got %q", "3 4\n", got)
+	}
+
+	if _, err := DecryptFile(k, encrypted); err == nil {
+		t.Error("DecryptFile overwrote the plain file")
+	}
+
+	if _, err := ReadFile(filepath.Join(t.TempDir(), "missing.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want %v, got %v", os.ErrNotExist, err)
+	}
+}
+
+func TestFindNewer(t *testing.T) {
+	k := testKey(t)
+	name := filepath.Join(t.TempDir(), "day01.txt")
+
+	if err := os.WriteFile(name, []byte("1 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EncryptFile(k, name, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// the plain file is edited after it was encrypted
+	if err := os.WriteFile(name, []byte("3 4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(name, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := ReadFile(name); err != nil || string(got) != "3 4\n" {
+		t.Errorf("want the edited plain file, got %q, %v", got, err)
+	}
+
+	// and encrypted again
+	if _, err := EncryptFile(k, name, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(name+Ext, later.Add(time.Minute), later.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if path, err := Find(name); err != nil || path != name+Ext {
+		t.Errorf("want %s, got %s, %v", name+Ext, path, err)
+	}
+}
@@ -0,0 +1,164 @@
+package submit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/secrets"
+)
+
+// HistoryFile is the name of the history file in the inputs directory.
+const HistoryFile = "history.jsonl"
+
+var (
+	ErrSolved     = errors.New("already solved")
+	ErrKnownWrong = errors.New("known to be wrong")
+	ErrOutOfRange = errors.New("outside the bounds of earlier attempts")
+	ErrWait       = errors.New("too soon after the previous attempt")
+)
+
+// Attempt is one answer sent to the site. Until is the time before which
+// the site won't take another answer.
+type Attempt struct {
+	Year   int       `json:"year"`
+	Day    int       `json:"day"`
+	Part   int       `json:"part"`
+	Answer string    `json:"answer"`
+	Result Result    `json:"result"`
+	Time   time.Time `json:"time"`
+	Until  time.Time `json:"until,omitzero"`
+}
+
+// History is the list of attempts, kept in a file with one JSON object per
+// line. As it holds the answers, it is encrypted like the answers files,
+// see secrets.Save.
+type History struct {
+	path     string
+	attempts []Attempt
+}
+
+// LoadHistory reads the history from path. A missing file is an empty
+// history.
+func LoadHistory(path string) (*History, error) {
+	h := &History{path: path}
+
+	data, err := secrets.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var a Attempt
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			return nil, &day.ParseError{File: path, Line: line, Err: err}
+		}
+
+		h.attempts = append(h.attempts, a)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return h, nil
+}
+
+// Attempts returns the attempts for a part of a day, oldest first.
+func (h *History) Attempts(year, number, part int) []Attempt {
+	var result []Attempt
+
+	for _, a := range h.attempts {
+		if a.Year == year && a.Day == number && a.Part == part {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+// Check returns an error when the history shows that sending answer can't
+// get a star: the part is solved, the answer was wrong before, or it lies
+// outside the bounds given by earlier too high and too low answers. It also
+// refuses answers while the site still asks to wait.
+func (h *History) Check(year, number, part int, answer day.Answer, now time.Time) error {
+	value, numeric := new(big.Int).SetString(answer.String(), 10)
+
+	for _, a := range h.attempts {
+		if a.Year != year || a.Day != number {
+			continue
+		}
+
+		if now.Before(a.Until) {
+			return fmt.Errorf("%w, wait until %s", ErrWait, a.Until.Format(time.TimeOnly))
+		}
+
+		if a.Part != part {
+			continue
+		}
+
+		switch {
+		case a.Result == Correct:
+			return fmt.Errorf("%w with %s", ErrSolved, a.Answer)
+		case a.Result.wrong() && a.Answer == answer.String():
+			return fmt.Errorf("%s is %w (%s)", answer, ErrKnownWrong, a.Result)
+		}
+
+		if !numeric || (a.Result != TooHigh && a.Result != TooLow) {
+			continue
+		}
+
+		bound, ok := new(big.Int).SetString(a.Answer, 10)
+		if !ok {
+			continue
+		}
+
+		if a.Result == TooHigh && value.Cmp(bound) > 0 || a.Result == TooLow && value.Cmp(bound) < 0 {
+			return fmt.Errorf("%s is %w: %s was %s", answer, ErrOutOfRange, a.Answer, a.Result)
+		}
+	}
+
+	return nil
+}
+
+// Add records an attempt and writes the history file again. An encrypted
+// file can't be appended to.
+func (h *History) Add(a Attempt) error {
+	attempts := append(h.attempts[:len(h.attempts):len(h.attempts)], a)
+
+	var b bytes.Buffer
+	for _, a := range attempts {
+		data, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+
+		b.Write(append(data, '\n'))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+
+	if err := secrets.Save(h.path, b.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	h.attempts = attempts
+	return nil
+}
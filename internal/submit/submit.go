@@ -0,0 +1,201 @@
+// Package submit posts answers to the Advent of Code website, and keeps a
+// history of every attempt, so that an answer that is known to be wrong is
+// never sent twice.
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/fetch"
+)
+
+type Result int
+
+const (
+	Unknown Result = iota
+	Correct
+	Wrong
+	TooHigh
+	TooLow
+	Wait
+	WrongLevel
+)
+
+var results = []string{"unknown", "correct", "wrong", "too high", "too low", "wait", "wrong level"}
+
+func (r Result) String() string {
+	if r < 0 || int(r) >= len(results) {
+		return "Result(" + strconv.Itoa(int(r)) + ")"
+	}
+
+	return results[r]
+}
+
+func (r Result) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Result) UnmarshalText(text []byte) error {
+	for i, s := range results {
+		if s == string(text) {
+			*r = Result(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown result %q", text)
+}
+
+// wrong reports whether the answer was rejected by the site. Answers that
+// weren't looked at, because they were sent too early, are not wrong.
+func (r Result) wrong() bool {
+	return r == Wrong || r == TooHigh || r == TooLow
+}
+
+var (
+	leftToWaitRE = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	waitRE       = regexp.MustCompile(`(?i)please wait (one|\d+) minutes?`)
+)
+
+// ParseResponse reads the result of an answer from the page the site
+// responds with, and how long to wait before sending another answer.
+func ParseResponse(body string) (Result, time.Duration) {
+	var result Result
+
+	switch {
+	case strings.Contains(body, "That's the right answer"):
+		return Correct, 0
+	case strings.Contains(body, "You don't seem to be solving the right level"):
+		return WrongLevel, 0
+	case strings.Contains(body, "your answer is too high"):
+		result = TooHigh
+	case strings.Contains(body, "your answer is too low"):
+		result = TooLow
+	case strings.Contains(body, "That's not the right answer"):
+		result = Wrong
+	case strings.Contains(body, "You gave an answer too recently"):
+		result = Wait
+	default:
+		return Unknown, 0
+	}
+
+	if m := leftToWaitRE.FindStringSubmatch(body); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		return result, time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+
+	if m := waitRE.FindStringSubmatch(body); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		return result, time.Duration(minutes) * time.Minute
+	}
+
+	return result, 0
+}
+
+type Submitter struct {
+	Client  *fetch.Client
+	History *History
+
+	// Now returns the current time, and defaults to time.Now.
+	Now func() time.Time
+}
+
+func (s *Submitter) now() time.Time {
+	if s.Now == nil {
+		return time.Now()
+	}
+
+	return s.Now()
+}
+
+// Submit sends the answer to a part of a day, unless the history shows that
+// it can't be right, and records the attempt.
+func (s *Submitter) Submit(ctx context.Context, year, number, part int, answer day.Answer) (Attempt, error) {
+	if part != 1 && part != 2 {
+		return Attempt{}, fmt.Errorf("no part %d", part)
+	}
+
+	if answer.String() == "" {
+		return Attempt{}, errors.New("empty answer")
+	}
+
+	if err := s.History.Check(year, number, part, answer, s.now()); err != nil {
+		return Attempt{}, err
+	}
+
+	body, err := s.post(ctx, year, number, part, answer)
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	result, wait := ParseResponse(body)
+	if result == Unknown {
+		return Attempt{}, errors.New("can't make sense of the response")
+	}
+
+	a := Attempt{
+		Year:   year,
+		Day:    number,
+		Part:   part,
+		Answer: answer.String(),
+		Result: result,
+		Time:   s.now(),
+	}
+	if wait > 0 {
+		a.Until = a.Time.Add(wait)
+	}
+
+	return a, s.History.Add(a)
+}
+
+func (s *Submitter) post(ctx context.Context, year, number, part int, answer day.Answer) (string, error) {
+	c := s.Client
+	if c.Session == "" {
+		return "", fetch.ErrNoSession
+	}
+
+	u := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimSuffix(c.BaseURL, "/"), year, number)
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer.String()},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", u, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", u, resp.Status)
+	}
+
+	return string(body), nil
+}
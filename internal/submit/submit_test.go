@@ -0,0 +1,200 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"adventofcode/internal/day"
+	"adventofcode/internal/fetch"
+	"adventofcode/internal/secrets"
+)
+
+const (
+	rightAnswer = `<article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to finding the Chief Historian.</p></article>`
+	tooHigh     = `<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2024/about">about page</a>.  Please wait one minute before trying again. <a href="/2024/day/7">[Return to Day 7]</a></p></article>`
+	tooLow      = `<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again. <a href="/2024/day/7">[Return to Day 7]</a></p></article>`
+	tooRecently = `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 38s left to wait. <a href="/2024/day/7">[Return to Day 7]</a></p></article>`
+	wrongLevel  = `<article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/7">[Return to Day 7]</a></p></article>`
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		body   string
+		result Result
+		wait   time.Duration
+	}{
+		{rightAnswer, Correct, 0},
+		{tooHigh, TooHigh, time.Minute},
+		{tooLow, TooLow, 5 * time.Minute},
+		{tooRecently, Wait, time.Minute + 38*time.Second},
+		{wrongLevel, WrongLevel, 0},
+		{"<html>something else</html>", Unknown, 0},
+	}
+
+	for _, test := range tests {
+		result, wait := ParseResponse(test.body)
+		if result != test.result || wait != test.wait {
+			t.Errorf("want %s, %s, got %s, %s", test.result, test.wait, result, wait)
+		}
+	}
+}
+
+type fakeSite struct {
+	responses []string
+	answers   []string
+}
+
+func (f *fakeSite) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/2024/day/7/answer" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.FormValue("level") != "1" {
+		http.Error(w, "bad level", http.StatusBadRequest)
+		return
+	}
+
+	f.answers = append(f.answers, r.FormValue("answer"))
+	w.Write([]byte(f.responses[0]))
+	f.responses = f.responses[1:]
+}
+
+func newTestSubmitter(t *testing.T, site *fakeSite, now *time.Time) *Submitter {
+	t.Helper()
+
+	server := httptest.NewServer(site)
+	t.Cleanup(server.Close)
+
+	c := fetch.New(t.TempDir(), "secret")
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.Interval = 0
+
+	// the history is encrypted only when a test sets a key
+	t.Setenv("AOC_KEY", "")
+	t.Setenv("AOC_KEY_FILE", filepath.Join(t.TempDir(), "key"))
+
+	h, err := LoadHistory(filepath.Join(t.TempDir(), HistoryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Submitter{c, h, func() time.Time { return *now }}
+}
+
+func TestSubmit(t *testing.T) {
+	now := time.Date(2024, 12, 7, 6, 0, 0, 0, time.UTC)
+	site := &fakeSite{responses: []string{tooHigh, tooLow, rightAnswer}}
+	s := newTestSubmitter(t, site, &now)
+	ctx := context.Background()
+
+	tests := []struct {
+		answer int
+		result Result
+		err    error
+		later  time.Duration
+	}{
+		{5000, TooHigh, nil, 0},
+		{4000, Unknown, ErrWait, time.Minute},
+		{5000, Unknown, ErrKnownWrong, 0},
+		{6000, Unknown, ErrOutOfRange, 0},
+		{1000, TooLow, nil, 5 * time.Minute},
+		{999, Unknown, ErrOutOfRange, 0},
+		{3749, Correct, nil, 0},
+		{3750, Unknown, ErrSolved, 0},
+	}
+
+	for _, test := range tests {
+		a, err := s.Submit(ctx, 2024, 7, 1, day.Int(test.answer))
+		if !errors.Is(err, test.err) {
+			t.Fatalf("answer %d: want error %v, got %v", test.answer, test.err, err)
+		}
+		if a.Result != test.result {
+			t.Errorf("answer %d: want %s, got %s", test.answer, test.result, a.Result)
+		}
+
+		now = now.Add(test.later)
+	}
+
+	want := []string{"5000", "1000", "3749"}
+	if len(site.answers) != len(want) {
+		t.Fatalf("want answers %v sent, got %v", want, site.answers)
+	}
+	for i := range want {
+		if site.answers[i] != want[i] {
+			t.Errorf("want answers %v sent, got %v", want, site.answers)
+		}
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	t.Setenv("AOC_KEY", "")
+	t.Setenv("AOC_KEY_FILE", filepath.Join(t.TempDir(), "key"))
+	path := filepath.Join(t.TempDir(), HistoryFile)
+
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 12, 7, 6, 0, 0, 0, time.UTC)
+	if err := h.Add(Attempt{Year: 2024, Day: 7, Part: 2, Answer: "42", Result: Wrong, Time: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err = LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Check(2024, 7, 2, day.Int(42), now); !errors.Is(err, ErrKnownWrong) {
+		t.Errorf("want ErrKnownWrong after reloading, got %v", err)
+	}
+	if err := h.Check(2024, 7, 1, day.Int(42), now); err != nil {
+		t.Errorf("want no error for the other part, got %v", err)
+	}
+}
+
+func TestHistoryEncrypted(t *testing.T) {
+	k, _ := secrets.NewKey()
+	t.Setenv("AOC_KEY", k.String())
+	path := filepath.Join(t.TempDir(), HistoryFile)
+
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 12, 7, 6, 0, 0, 0, time.UTC)
+	for _, a := range []Attempt{
+		{Year: 2024, Day: 7, Part: 1, Answer: "12345677", Result: TooLow, Time: now},
+		{Year: 2024, Day: 7, Part: 1, Answer: "12345678", Result: Correct, Time: now},
+	} {
+		if err := h.Add(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// answers, right or wrong, are never kept in plain text
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want no plain history, got %v", err)
+	}
+	if data, err := os.ReadFile(path + secrets.Ext); err != nil || strings.Contains(string(data), "12345678") {
+		t.Errorf("want an encrypted history, got %q, %v", data, err)
+	}
+
+	h, err = LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Attempts(2024, 7, 1); len(got) != 2 {
+		t.Errorf("want both attempts after reloading, got %v", got)
+	}
+}